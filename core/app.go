@@ -13,7 +13,6 @@ import (
 	"log"
 	"net"
 	"net/http"
-	"net/url"
 	"os"
 	"os/signal"
 	"strings"
@@ -21,70 +20,20 @@ import (
 	"text/template"
 	"time"
 
-	"github.com/asaskevich/EventBus"
 	"golang.org/x/net/websocket"
 )
 
-type WidgetID int
-
-func (id WidgetID) String() string {
-	return fmt.Sprintf("oden-%d", id)
-}
-
-var widgetID WidgetID
-
-var idMutex sync.Mutex
-
-func NewWidgetID() WidgetID {
-	idMutex.Lock()
-	defer idMutex.Unlock()
-	widgetID++
-	return widgetID
-}
-
 type Widget interface {
 	ID() WidgetID
 	View() string
 	Attach(app *App)
 }
 
-type rawEvent struct {
-	Target    string                 `json:"target"`
-	EventName string                 `json:"event"`
-	Props     map[string]interface{} `json:"props"`
-}
-
-func (e *rawEvent) ID() string {
-	return fmt.Sprintf("%s.%s", e.Target, e.EventName)
-}
-
-type Event interface {
-	ID() string
-	Target() Widget
-	EventName() string
-	Props() map[string]interface{}
-}
-
-type actualEvent struct {
-	target    Widget
-	eventName string
-	props     map[string]interface{}
-}
-
-func (e *actualEvent) ID() string {
-	return fmt.Sprintf("%s.%s", e.target.ID(), e.eventName)
-}
-
-func (e *actualEvent) Target() Widget {
-	return e.target
-}
-
-func (e *actualEvent) EventName() string {
-	return e.eventName
-}
-
-func (e *actualEvent) Props() map[string]interface{} {
-	return e.props
+// Detacher is implemented by the widgets that stop listening to their models
+// and handling events when they are detached. The view of a window is
+// detached when the window is closed.
+type Detacher interface {
+	Detach()
 }
 
 type App struct {
@@ -93,16 +42,41 @@ type App struct {
 	cancel   context.CancelFunc
 	server   *http.Server
 	listener net.Listener
-	width    int
-	height   int
-	view     Widget
-	widgetID int
-	msgs     chan string
+	token    string
+	tmpl     *template.Template
+	browser  browser
+	mu       sync.Mutex
+	main     *Window
+	windows  map[int]*Window
+	windowID int
+	started  bool
+	serving  bool
+	options  *AppOptions
+	ids      *IDAllocator
+	bus      *eventBus
+
+	shortcuts map[shortcut][]*shortcutHandler
+	updates   *updateQueue
+
+	errorHandler     func(err error)
+	reconnectTimeout time.Duration
 }
 
-func NewApp(name string, width, height int, view Widget) *App {
+// NewApp returns an App showing view in its main window.
+// The App is configured by options such as WithSize and WithBrowser.
+func NewApp(name string, view Widget, options ...func(*AppOptions)) *App {
+	o := defaultAppOptions()
+	for _, option := range options {
+		option(o)
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
-	listener, err := net.Listen("tcp", ":0")
+	listener, err := net.Listen("tcp", o.addr)
+	if err != nil {
+		panic(err)
+	}
+
+	tmpl, err := template.New("index").Funcs(template.FuncMap{"json": toJSON}).Parse(indexTmpl)
 	if err != nil {
 		panic(err)
 	}
@@ -112,44 +86,58 @@ func NewApp(name string, width, height int, view Widget) *App {
 		name:     name,
 		ctx:      ctx,
 		cancel:   cancel,
-		server:   &http.Server{Addr: listener.Addr().String(), Handler: mux},
 		listener: listener,
-		width:    width,
-		height:   height,
-		view:     view,
-		widgetID: 0,
-		msgs:     make(chan string),
-	}
+		token:    newToken(),
+		tmpl:     tmpl,
+		windows:  make(map[int]*Window),
+		options:  o,
+		ids:      o.ids,
+		bus:      newEventBus(),
 
-	topWidget := view
+		shortcuts: make(map[shortcut][]*shortcutHandler),
+		updates:   newUpdateQueue(),
 
-	tmpl, err := template.New("index").Parse(indexTmpl)
-	if err != nil {
-		panic(err)
+		reconnectTimeout: defaultReconnectTimeout,
 	}
+	if app.ids == nil {
+		app.ids = NewIDAllocator()
+	}
+
+	app.server = &http.Server{Addr: listener.Addr().String(), Handler: app.authenticate(mux)}
+	app.main = app.newWindow(o.windowConfig(name), view)
+	app.main.attach()
 
 	assetHandler := http.FileServer(assetsFS)
 	mux.Handle("/assets/", assetHandler)
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		ipAddr := getIPAddr(r)
-		if !(ipAddr == "[::1]" || ipAddr == "127.0.0.1" || ipAddr == "localhost" || ipAddr == "::1") {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
 			return
 		}
-		err := tmpl.Execute(w, &templateParams{
-			Name:         app.name,
-			HeadElements: headElements,
-			Events:       targetEvents,
-			Widget:       topWidget.View(),
-			Port:         app.port(),
-		})
-		if err != nil {
-			log.Printf("failed to execute the index template: %v", err)
-		}
+		app.serveWindow(app.main, w, r)
 	})
-	mux.Handle("/ws", websocket.Handler(app.handleWebSocket))
-	mux.HandleFunc("/turbo.es2017-umd.js", func(w http.ResponseWriter, r *http.Request) {
-		w.Write([]byte(turbo))
+	mux.HandleFunc("/windows/", func(w http.ResponseWriter, r *http.Request) {
+		win, rest := app.lookupWindow(r.URL.Path)
+		if win == nil {
+			http.NotFound(w, r)
+			return
+		}
+		switch rest {
+		case "":
+			app.serveWindow(win, w, r)
+		case "ws":
+			app.websocketHandler(win).ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
 	})
+	mux.Handle("/ws", app.websocketHandler(app.main))
+	if o.icon != nil {
+		mux.HandleFunc("/icon", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", http.DetectContentType(o.icon))
+			w.Write(o.icon)
+		})
+	}
 	return app
 }
 
@@ -163,116 +151,241 @@ func getIPAddr(r *http.Request) string {
 	return strings.Join(nameParts, ":")
 }
 
-func (app *App) handleWebSocket(ws *websocket.Conn) {
+func (app *App) serveWindow(win *Window, w http.ResponseWriter, r *http.Request) {
+	var view string
+	app.call(func() {
+		view = win.View()
+	})
+	win.track(view)
+	err := app.tmpl.Execute(w, &templateParams{
+		Name:         win.config.title,
+		HeadElements: app.headElements(),
+		Events:       targetEvents,
+		Widget:       view,
+		WSPath:       win.path() + "ws",
+	})
+	if err != nil {
+		app.reportError(fmt.Errorf("failed to execute the index template: %w", err))
+	}
+}
+
+func (app *App) handleWebSocket(win *Window, ws *websocket.Conn) {
 	defer ws.Close()
 
-	u, _ := url.Parse(ws.RemoteAddr().String())
-	hostname := u.Hostname()
-	if !(hostname == "localhost" || hostname == "127.0.0.1") {
-		return
+	if win == app.main {
+		app.openPendingWindows()
 	}
 
-	go func() {
-		for {
-			var r string
-			err := websocket.Message.Receive(ws, &r)
-			if err != nil {
-				if err == io.EOF {
-					app.cancel()
-					return
-				}
-				log.Fatal(err)
-			}
+	c := win.hub.add(ws, clientQueueSize)
+	defer app.disconnect(win, c)
+
+	// The page may be stale if it was rendered before a reconnection
+	// or updated before this client connected, so resynchronise it first.
+	app.call(func() {
+		c.msgs <- win.syncMessage()
+		c.msgs <- app.eventsCommand()
+	})
+	c.msgs <- app.shortcutsCommand()
+
+	go app.receive(win, c)
 
-			var ev rawEvent
-			err = json.Unmarshal([]byte(r), &ev)
+	for {
+		select {
+		case msg := <-c.msgs:
+			err := websocket.Message.Send(ws, msg)
 			if err != nil {
-				log.Fatal(err)
+				app.reportError(fmt.Errorf("failed to send a message to the window %q: %w", win.config.title, err))
+				return
 			}
-
-			bus.Publish(ev.ID(), &ev)
+		case <-c.done:
+			return
+		case <-win.done:
+			flush(ws, c.msgs)
+			return
 		}
-	}()
+	}
+}
+
+// receive publishes the events sent by the client until the connection is closed.
+// Malformed events are reported and dropped.
+func (app *App) receive(win *Window, c *client) {
+	defer c.close()
 
 	for {
-		msg := <-app.msgs
-		err := websocket.Message.Send(ws, msg)
+		var r string
+		err := websocket.Message.Receive(c.ws, &r)
+		if err != nil {
+			select {
+			case <-win.done:
+			case <-c.done:
+			default:
+				if err != io.EOF {
+					app.reportError(fmt.Errorf("failed to receive a message from the window %q: %w", win.config.title, err))
+				}
+			}
+			return
+		}
+
+		var ev rawEvent
+		err = json.Unmarshal([]byte(r), &ev)
 		if err != nil {
-			log.Fatal(err)
+			app.reportError(fmt.Errorf("dropped a malformed event %q: %w", r, err))
+			continue
+		}
+
+		app.Dispatch(func() {
+			app.handleEvent(&ev)
+		})
+	}
+}
+
+// handleEvent runs the handlers of ev in a batch. It is called on the UI goroutine.
+func (app *App) handleEvent(ev *rawEvent) {
+	app.Batch(func() {
+		if ev.Shortcut != "" {
+			app.runShortcut(ev)
+			return
+		}
+		app.bus.publish(ev)
+	})
+}
+
+// defaultReconnectTimeout is how long a window without clients waits for its
+// page to reconnect, e.g. after a reload, before the window is considered closed.
+const defaultReconnectTimeout = 3 * time.Second
+
+func (app *App) disconnect(win *Window, c *client) {
+	if win.hub.remove(c) > 0 {
+		return
+	}
+	time.AfterFunc(app.reconnectTimeout, func() {
+		if win.hub.count() == 0 {
+			app.closeWindow(win)
 		}
+	})
+}
+
+// OnError sets the function called when an error occurs while communicating
+// with the browser. Such errors don't stop the App; by default they are logged.
+func (app *App) OnError(handler func(err error)) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	app.errorHandler = handler
+}
+
+func (app *App) reportError(err error) {
+	app.mu.Lock()
+	handler := app.errorHandler
+	app.mu.Unlock()
+
+	if handler == nil {
+		log.Print(err)
+		return
 	}
+	handler(err)
+}
 
+// flush sends the messages left in msgs, such as the command closing the window.
+func flush(ws *websocket.Conn, msgs chan string) {
+	for {
+		select {
+		case msg := <-msgs:
+			if err := websocket.Message.Send(ws, msg); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
 }
 
 //go:embed index.html
 var indexTmpl string
 
-//go:embed turbo.es2017-umd.js
-var turbo string
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
 
 type templateParams struct {
 	Name         string
 	HeadElements string
 	Events       []TargetEvent
 	Widget       string
-	Port         int
+	WSPath       string
 }
 
 func (app *App) serve() {
-	app.view.Attach(app)
 	app.server.Serve(app.listener)
 }
 
+// quit makes the App quit. The listener of an App that isn't served, e.g.
+// one driven by tests, is closed here; Run shuts the server down otherwise.
+func (app *App) quit() {
+	app.cancel()
+
+	app.mu.Lock()
+	serving := app.serving
+	app.mu.Unlock()
+	if !serving {
+		app.listener.Close()
+	}
+}
+
 func (app *App) port() int {
 	return app.listener.Addr().(*net.TCPAddr).Port
 }
 
+// Run opens the main window and blocks until the App quits, which happens when
+// the main window is closed, the browser exits or the process is interrupted.
+// The browser is terminated when the App quits.
 func (app *App) Run() {
-	go app.serve()
-	browser := detectBrowser()
+	if err := app.run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run is Run returning its error once the temporary profile of the browser
+// is removed, which exiting in Run would skip.
+func (app *App) run() error {
+	profileDir, removeProfile, err := app.options.profile()
+	if err != nil {
+		return fmt.Errorf("failed to create the browser profile: %w", err)
+	}
+	defer removeProfile()
+
+	browser := selectBrowser(app.options, profileDir)
 	if browser == nil {
-		log.Fatal("any supported browser not found")
-		return
+		return fmt.Errorf("any supported browser not found (set %s to choose a browser or its executable)", BrowserEnv)
 	}
-	browser.open(app.name, app.port(), app.width, app.height)
 
-	quit := make(chan os.Signal)
+	app.mu.Lock()
+	app.serving = true
+	app.browser = browser
+	app.mu.Unlock()
+	go app.serve()
+	app.main.open()
+
+	go func() {
+		browser.wait()
+		app.cancel()
+	}()
+
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 	select {
 	case <-quit:
 	case <-app.ctx.Done():
 	}
 
+	browser.terminate()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	if err := app.server.Shutdown(ctx); err != nil {
-		log.Fatal(err)
-	}
-}
-
-func (app *App) PostUpdate(w Widget) {
-	app.msgs <- fmt.Sprintf(
-		`<turbo-stream action="replace" target="oden-%d"><template>%s</template></turbo-stream>`,
-		w.ID(),
-		w.View(),
-	)
-}
-
-var bus = EventBus.New()
-
-func AddEventHandler(w Widget, event string, handler func(ev Event)) {
-	bus.Subscribe(
-		fmt.Sprintf("%d.%s", w.ID(), event),
-		func(rev *rawEvent) {
-			ev := &actualEvent{
-				target:    w,
-				eventName: rev.EventName,
-				props:     rev.Props,
-			}
-			ev.target = w
-			handler(ev)
-		},
-	)
+	return app.server.Shutdown(ctx)
 }
 
 var headElements string
@@ -281,9 +394,27 @@ func SetHeadElements(s string) {
 	headElements = s
 }
 
+func (app *App) headElements() string {
+	s := headElements + app.options.headElements
+	if app.options.icon != nil {
+		s += `<link rel="icon" href="icon">`
+	}
+	return s
+}
+
+// TargetEvent is a DOM event forwarded to the Go side.
+// A page only forwards the event for the widgets having a handler for it.
+// PropNames are the properties of the event target sent along with the event,
+// and DetailNames the fields of the detail of a custom event, which are sent
+// as props too.
+// If PreventDefault is true, the default action of the event is prevented
+// in the browser for those widgets, so that the handlers decide what happens
+// instead.
 type TargetEvent struct {
-	Name     string
-	PropName string
+	Name           string
+	PropNames      []string
+	DetailNames    []string
+	PreventDefault bool
 }
 
 var targetEvents []TargetEvent
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// testWidget is a widget whose view is made by a function of its ID,
+// so that tests can change what it renders.
+type testWidget struct {
+	id       WidgetID
+	view     func(id WidgetID) string
+	children []*testWidget
+}
+
+func newTestWidget(view func(id WidgetID) string, children ...*testWidget) *testWidget {
+	return &testWidget{view: view, children: children}
+}
+
+func (w *testWidget) ID() WidgetID {
+	return w.id
+}
+
+func (w *testWidget) View() string {
+	return w.view(w.id)
+}
+
+func (w *testWidget) Attach(app *App) {
+	w.id = app.IDs().Next()
+	for _, c := range w.children {
+		c.Attach(app)
+	}
+}
+
+// newTestApp returns an App showing view and a Conn to its main window,
+// whose first message, the sync of the page, is already received.
+func newTestApp(t *testing.T, view Widget) (*App, *Conn) {
+	t.Helper()
+
+	app := NewApp(t.Name(), view)
+	conn := app.MainWindow().Connect()
+	t.Cleanup(func() {
+		conn.Close()
+		app.MainWindow().Close()
+	})
+	if msg, ok := conn.Receive(); !ok || !strings.Contains(msg, `"sync"`) {
+		t.Fatalf("first message = %q, want a sync", msg)
+	}
+	return app, conn
+}
+
+// receiveAll returns the messages received by conn after flushing the updates.
+func receiveAll(app *App, conn *Conn) []string {
+	app.Flush()
+	var msgs []string
+	for {
+		msg, ok := conn.Receive()
+		if !ok {
+			return msgs
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+func TestClosingMainWindowClosesListener(t *testing.T) {
+	app := NewApp(t.Name(), newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<div id="%s"></div>`, id)
+	}))
+	addr := app.listener.Addr().String()
+	app.MainWindow().Close()
+
+	select {
+	case <-app.ctx.Done():
+	default:
+		t.Error("App not quit by closing the main window")
+	}
+	if c, err := net.Dial("tcp", addr); err == nil {
+		c.Close()
+		t.Errorf("listener of a quit App still accepts connections")
+	}
+}
+
+// serveTestApp serves app and returns a function opening a WebSocket
+// to its main window, as its page does.
+func serveTestApp(t *testing.T, app *App) func() *websocket.Conn {
+	t.Helper()
+
+	// The updates made before connecting are already in the first sync.
+	app.Flush()
+	go app.server.Serve(app.listener)
+	t.Cleanup(func() {
+		app.MainWindow().Close()
+		app.server.Close()
+	})
+
+	origin := "http://" + app.listener.Addr().String()
+	return func() *websocket.Conn {
+		t.Helper()
+
+		url := "ws://" + app.listener.Addr().String() + "/ws?token=" + app.token
+		ws, err := websocket.Dial(url, "", origin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			ws.Close()
+		})
+		// The page is synchronised, and told the events and shortcuts it sends.
+		for _, command := range []string{"sync", "events", "shortcuts"} {
+			var msg string
+			if err := websocket.Message.Receive(ws, &msg); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(msg, `"`+command+`"`) {
+				t.Fatalf("message %q, want a %s command", msg, command)
+			}
+		}
+		return ws
+	}
+}
+
+func TestMalformedEventIsReported(t *testing.T) {
+	button := newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<button id="%s">OK</button>`, id)
+	})
+	app := NewApp(t.Name(), button)
+	errs := make(chan error, 10)
+	app.OnError(func(err error) {
+		errs <- err
+	})
+	clicks := make(chan struct{}, 10)
+	app.AddEventHandler(button, "click", func(Event) {
+		clicks <- struct{}{}
+	})
+	ws := serveTestApp(t, app)()
+
+	if err := websocket.Message.Send(ws, `{"target": `); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "malformed event") {
+			t.Errorf("error %q reported, want a malformed event", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("malformed event not reported")
+	}
+
+	// The connection is kept, and the next events are handled.
+	click := fmt.Sprintf(`{"target": "%d", "event": "click", "props": {}}`, int(button.ID()))
+	if err := websocket.Message.Send(ws, click); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-clicks:
+	case <-time.After(5 * time.Second):
+		t.Fatal("click after a malformed event not handled")
+	}
+	select {
+	case err := <-errs:
+		t.Errorf("error %q reported for a valid event", err)
+	default:
+	}
+}
+
+func TestReconnectTimeout(t *testing.T) {
+	view := newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<div id="%s"></div>`, id)
+	})
+
+	t.Run("reconnected", func(t *testing.T) {
+		app := NewApp(t.Name(), view)
+		app.reconnectTimeout = 100 * time.Millisecond
+		dial := serveTestApp(t, app)
+
+		// The page reloads: it disconnects and connects again in time.
+		dial().Close()
+		dial()
+		time.Sleep(3 * app.reconnectTimeout)
+		select {
+		case <-app.ctx.Done():
+			t.Error("App quit although its page reconnected")
+		default:
+		}
+	})
+
+	t.Run("gone", func(t *testing.T) {
+		app := NewApp(t.Name(), view)
+		app.reconnectTimeout = 100 * time.Millisecond
+		dial := serveTestApp(t, app)
+
+		dial().Close()
+		select {
+		case <-app.ctx.Done():
+		case <-time.After(5 * time.Second):
+			t.Error("App still running after its page left")
+		}
+	})
+}
+
+func TestRunRemovesProfileWithoutBrowser(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	t.Setenv("TMP", tmp)
+	t.Setenv(BrowserEnv, filepath.Join(tmp, "missing"))
+	app := NewApp(t.Name(), newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<div id="%s"></div>`, id)
+	}))
+	t.Cleanup(func() {
+		app.MainWindow().Close()
+	})
+
+	if err := app.run(); err == nil || !strings.Contains(err.Error(), BrowserEnv) {
+		t.Errorf("run() = %v, want an error about the missing browser", err)
+	}
+	entries, err := os.ReadDir(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		t.Errorf("%s left in the temporary directory", e.Name())
+	}
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/net/websocket"
+)
+
+// newToken returns a random secret identifying the browser windows launched by an App.
+func newToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func (app *App) tokenCookieName() string {
+	return fmt.Sprintf("oden-token-%d", app.port())
+}
+
+func (app *App) validToken(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(app.token)) == 1
+}
+
+// authenticate rejects requests that don't carry the App's token.
+// The token is passed to the browser in the URL of each window and is
+// kept in a cookie, so that assets loaded by the page are accepted too.
+func (app *App) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ipAddr := getIPAddr(r)
+		if !(ipAddr == "[::1]" || ipAddr == "127.0.0.1" || ipAddr == "localhost" || ipAddr == "::1") {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		if token := r.URL.Query().Get("token"); token != "" && app.validToken(token) {
+			http.SetCookie(w, &http.Cookie{
+				Name:     app.tokenCookieName(),
+				Value:    app.token,
+				Path:     "/",
+				HttpOnly: true,
+				SameSite: http.SameSiteStrictMode,
+			})
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if c, err := r.Cookie(app.tokenCookieName()); err == nil && app.validToken(c.Value) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		http.Error(w, "forbidden", http.StatusForbidden)
+	})
+}
+
+// checkOrigin accepts WebSocket connections only from the App's own pages.
+func checkOrigin(config *websocket.Config, r *http.Request) error {
+	origin, err := websocket.Origin(config, r)
+	if err != nil {
+		return err
+	}
+	if origin == nil || origin.Host != r.Host {
+		return fmt.Errorf("unexpected origin: %v", origin)
+	}
+	config.Origin = origin
+	return nil
+}
+
+func (app *App) websocketHandler(win *Window) http.Handler {
+	return websocket.Server{
+		Handshake: checkOrigin,
+		Handler: func(ws *websocket.Conn) {
+			app.handleWebSocket(win, ws)
+		},
+	}
+}
+
+// windowURL returns the URL opening win in a browser. Browsers started by
+// the App get it through a launch page rather than their command line.
+func (app *App) windowURL(win *Window) string {
+	addr := app.listener.Addr().(*net.TCPAddr)
+	host := "127.0.0.1"
+	if !addr.IP.IsUnspecified() {
+		host = addr.IP.String()
+	}
+	return fmt.Sprintf(
+		"http://%s%s?token=%s",
+		net.JoinHostPort(host, strconv.Itoa(addr.Port)),
+		win.path(),
+		url.QueryEscape(app.token),
+	)
+}
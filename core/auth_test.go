@@ -0,0 +1,90 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestAuthenticate(t *testing.T) {
+	app, _ := newTestApp(t, newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<div id="%s"></div>`, id)
+	}))
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := app.authenticate(ok)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		url        string
+		cookie     *http.Cookie
+		want       int
+		setsCookie bool
+	}{
+		{"token", "127.0.0.1:5000", "/?token=" + app.token, nil, http.StatusOK, true},
+		{"token over IPv6", "[::1]:5000", "/?token=" + app.token, nil, http.StatusOK, true},
+		{"no token", "127.0.0.1:5000", "/", nil, http.StatusForbidden, false},
+		{"wrong token", "127.0.0.1:5000", "/?token=x" + app.token, nil, http.StatusForbidden, false},
+		{"empty token", "127.0.0.1:5000", "/?token=", nil, http.StatusForbidden, false},
+		{"cookie", "127.0.0.1:5000", "/assets/a.js", &http.Cookie{Name: app.tokenCookieName(), Value: app.token}, http.StatusOK, false},
+		{"bad cookie", "127.0.0.1:5000", "/assets/a.js", &http.Cookie{Name: app.tokenCookieName(), Value: "bad"}, http.StatusForbidden, false},
+		{"cookie of another app", "127.0.0.1:5000", "/assets/a.js", &http.Cookie{Name: "oden-token-1", Value: app.token}, http.StatusForbidden, false},
+		{"non-local IP with token", "192.168.1.10:5000", "/?token=" + app.token, nil, http.StatusForbidden, false},
+		{"non-local IP with cookie", "10.0.0.1:5000", "/", &http.Cookie{Name: app.tokenCookieName(), Value: app.token}, http.StatusForbidden, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.cookie != nil {
+				r.AddCookie(tt.cookie)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+			var setsCookie bool
+			for _, c := range w.Result().Cookies() {
+				if c.Name == app.tokenCookieName() && c.Value == app.token && c.HttpOnly {
+					setsCookie = true
+				}
+			}
+			if setsCookie != tt.setsCookie {
+				t.Errorf("sets the token cookie = %v, want %v", setsCookie, tt.setsCookie)
+			}
+		})
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	tests := []struct {
+		name   string
+		origin string
+		ok     bool
+	}{
+		{"same origin", "http://127.0.0.1:8000", true},
+		{"foreign origin", "http://evil.example:8000", false},
+		{"other port", "http://127.0.0.1:9000", false},
+		{"no origin", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8000/ws", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			config := &websocket.Config{Version: websocket.ProtocolVersionHybi13}
+			err := checkOrigin(config, r)
+			if (err == nil) != tt.ok {
+				t.Errorf("checkOrigin() = %v, want ok = %v", err, tt.ok)
+			}
+		})
+	}
+}
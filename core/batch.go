@@ -0,0 +1,222 @@
+package core
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// frameInterval is the interval at which pending updates are sent to the pages.
+const frameInterval = 16 * time.Millisecond
+
+// updateQueue holds the widgets to re-render until the next frame.
+type updateQueue struct {
+	mu        sync.Mutex
+	dirty     map[WidgetID]Widget
+	shown     map[WidgetID]Widget
+	depth     int
+	scheduled bool
+}
+
+func newUpdateQueue() *updateQueue {
+	return &updateQueue{
+		dirty: make(map[WidgetID]Widget),
+		shown: make(map[WidgetID]Widget),
+	}
+}
+
+// PostUpdate re-renders w in the windows showing it.
+// Updates are coalesced and sent once per frame: a widget updated several
+// times is rendered once, and a widget is not rendered by itself if one
+// of its ancestors is updated too. Only the differences from the previous
+// rendering are sent, so that the elements of the page keep their state
+// such as the focus.
+func (app *App) PostUpdate(w Widget) {
+	q := app.updates
+	q.mu.Lock()
+	q.dirty[w.ID()] = w
+	q.mu.Unlock()
+	app.scheduleFlush()
+}
+
+// PostShown records that the pages already show the current view of w,
+// e.g. a value entered by the user, so that the view is not sent to them.
+// Later updates of w are computed from that view.
+func (app *App) PostShown(w Widget) {
+	q := app.updates
+	q.mu.Lock()
+	q.shown[w.ID()] = w
+	q.mu.Unlock()
+	app.scheduleFlush()
+}
+
+// Batch runs f and holds the updates posted by f until it returns,
+// so that they are sent to the pages together. Event handlers are
+// run in a batch. Batches may be nested.
+// Batch is to be called on the UI goroutine; see Dispatch.
+func (app *App) Batch(f func()) {
+	q := app.updates
+	q.mu.Lock()
+	q.depth++
+	q.mu.Unlock()
+
+	defer func() {
+		q.mu.Lock()
+		q.depth--
+		q.mu.Unlock()
+		app.scheduleFlush()
+	}()
+	f()
+}
+
+// scheduleFlush schedules a flush at the next frame if some updates or
+// changes of the event handlers are pending and no batch is running.
+func (app *App) scheduleFlush() {
+	q := app.updates
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.scheduled || q.depth > 0 || len(q.dirty) == 0 && len(q.shown) == 0 && !app.bus.hasChanged() {
+		return
+	}
+	q.scheduled = true
+	time.AfterFunc(frameInterval, func() {
+		app.Dispatch(app.flush)
+	})
+}
+
+// Flush sends the pending updates to the pages now, without waiting for the next frame.
+func (app *App) Flush() {
+	app.call(app.flush)
+}
+
+// flush renders the pending updates. It is called on the UI goroutine.
+func (app *App) flush() {
+	q := app.updates
+	q.mu.Lock()
+	dirty, shown := q.dirty, q.shown
+	q.dirty = make(map[WidgetID]Widget)
+	q.shown = make(map[WidgetID]Widget)
+	q.scheduled = false
+	q.mu.Unlock()
+
+	// The pages learn about new handlers before they get the widgets
+	// handling the events.
+	if app.bus.hasChanged() {
+		app.broadcast(app.eventsCommand())
+	}
+
+	// Bring the copies of the pages up to date with what they already show
+	// before computing the patches.
+	// unsynced holds the windows whose pages are synced instead of patched,
+	// with the views which couldn't be patched.
+	unsynced := make(map[*Window]map[WidgetID]string)
+	markUnsynced := func(win *Window, id WidgetID, view string) {
+		if unsynced[win] == nil {
+			unsynced[win] = make(map[WidgetID]string)
+		}
+		unsynced[win][id] = view
+	}
+	for id, w := range shown {
+		if _, ok := dirty[id]; ok {
+			continue
+		}
+		view := w.View()
+		for _, win := range app.windowsShowing(id) {
+			if _, err := win.patch(id, view); err == errUnknownRun {
+				markUnsynced(win, id, view)
+			}
+		}
+	}
+	defer func() {
+		// Pages whose nodes can't be patched get their whole view again,
+		// after which the nodes of the widgets are known.
+		for win, views := range unsynced {
+			win.send(win.syncMessage())
+			for id, view := range views {
+				win.learnRun(id, view)
+			}
+		}
+	}()
+	if len(dirty) == 0 {
+		return
+	}
+
+	// Ancestors are attached before their descendants, so they usually have
+	// smaller IDs; rendering them first lets most descendants be skipped.
+	ids := make([]WidgetID, 0, len(dirty))
+	for id := range dirty {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	type update struct {
+		id   WidgetID
+		view string
+	}
+	var updates []update
+	covered := make(map[WidgetID]bool)
+	for _, id := range ids {
+		if covered[id] {
+			continue
+		}
+		view := dirty[id].View()
+		for _, contained := range containedWidgets(view) {
+			if contained != id {
+				covered[contained] = true
+			}
+		}
+		updates = append(updates, update{id, view})
+	}
+
+	patches := make(map[*Window][]*patch)
+	var order []*Window
+	for _, u := range updates {
+		if covered[u.id] {
+			continue
+		}
+		for _, win := range app.windowsShowing(u.id) {
+			if unsynced[win] != nil {
+				markUnsynced(win, u.id, u.view)
+				continue
+			}
+			p, err := win.patch(u.id, u.view)
+			if err == errUnknownRun {
+				markUnsynced(win, u.id, u.view)
+				continue
+			}
+			if err != nil || len(p.Ops) == 0 {
+				continue
+			}
+			if _, ok := patches[win]; !ok {
+				order = append(order, win)
+			}
+			patches[win] = append(patches[win], p)
+		}
+	}
+	for _, win := range order {
+		if unsynced[win] != nil {
+			continue
+		}
+		win.send(command(map[string]interface{}{
+			"command": "patch",
+			"patches": patches[win],
+		}))
+	}
+}
+
+// containedWidgets returns the IDs of the widgets in html.
+func containedWidgets(html string) []WidgetID {
+	var ids []WidgetID
+	for _, m := range widgetIDPattern.FindAllStringSubmatch(html, -1) {
+		id, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		ids = append(ids, WidgetID(id))
+	}
+	return ids
+}
@@ -2,235 +2,485 @@ package core
 
 import (
 	"fmt"
+	"html"
+	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
 )
 
+// BrowserKind is a kind of browser used to show the windows of an App.
+type BrowserKind int
+
+const (
+	// AnyBrowser selects the first browser found in the order of priority.
+	AnyBrowser BrowserKind = iota
+	WebView2
+	Chrome
+	Edge
+	Chromium
+	Firefox
+	Brave
+	Vivaldi
+)
+
+func (k BrowserKind) String() string {
+	switch k {
+	case AnyBrowser:
+		return "any"
+	case WebView2:
+		return "webview2"
+	case Chrome:
+		return "chrome"
+	case Edge:
+		return "edge"
+	case Chromium:
+		return "chromium"
+	case Firefox:
+		return "firefox"
+	case Brave:
+		return "brave"
+	case Vivaldi:
+		return "vivaldi"
+	}
+	return ""
+}
+
+// ParseBrowserKind returns the BrowserKind whose String is s, ignoring case.
+func ParseBrowserKind(s string) (BrowserKind, bool) {
+	for k := AnyBrowser; k <= Vivaldi; k++ {
+		if strings.EqualFold(s, k.String()) {
+			return k, true
+		}
+	}
+	return AnyBrowser, false
+}
+
+// BrowserEnv is the environment variable overriding the browser selected by
+// WithBrowser or WithBrowserExecutable. Its value is either the name of a
+// BrowserKind, such as "chromium", or the path of a browser executable.
+const BrowserEnv = "ODEN_BROWSER"
+
 type browser interface {
-	open(title string, port, width, height int)
+	open(url string, config *windowConfig) error
+	// wait blocks until the browser started by the first call of open exits.
+	wait()
+	// terminate closes the browser and waits for it to exit.
+	terminate()
+}
+
+// browserCandidates are the browsers detected in the order of priority
+// after WebView2. Each one is looked up in its usual install locations
+// first, then by name in the PATH.
+var browserCandidates = []struct {
+	kind  BrowserKind
+	paths func() []string
+	names []string
+}{
+	{Chrome, chromePaths, []string{"google-chrome-stable", "google-chrome", "chrome"}},
+	{Edge, edgePaths, []string{"microsoft-edge-stable", "microsoft-edge", "msedge"}},
+	{Chromium, chromiumPaths, []string{"chromium", "chromium-browser"}},
+	{Brave, bravePaths, []string{"brave-browser", "brave"}},
+	{Vivaldi, vivaldiPaths, []string{"vivaldi-stable", "vivaldi"}},
+	{Firefox, firefoxPaths, []string{"firefox"}},
 }
 
-func detectBrowser() browser {
-	if runtime.GOOS == "windows" {
+// selectBrowser returns the browser chosen by BrowserEnv or the App's options.
+// Chromium-based browsers and Firefox use profileDir as their profile.
+func selectBrowser(o *AppOptions, profileDir string) browser {
+	kind, execPath := o.browser, o.browserPath
+	if env := os.Getenv(BrowserEnv); env != "" {
+		if k, ok := ParseBrowserKind(env); ok {
+			kind, execPath = k, ""
+		} else {
+			kind, execPath = AnyBrowser, env
+		}
+	}
+	return detectBrowser(kind, execPath, profileDir)
+}
+
+func detectBrowser(kind BrowserKind, execPath, profileDir string) browser {
+	if execPath != "" {
+		path, err := exec.LookPath(execPath)
+		if err != nil {
+			return nil
+		}
+		if kind == AnyBrowser || kind == WebView2 {
+			kind = guessBrowserKind(path)
+		}
+		return newBrowser(kind, path, profileDir)
+	}
+
+	if (kind == AnyBrowser && runtime.GOOS == "windows") || kind == WebView2 {
 		w := detectWebview2()
 		if w != nil {
 			return w
 		}
 	}
 
-	chrome := detectChrome()
-	if chrome != nil {
-		return chrome
+	for _, c := range browserCandidates {
+		if kind != AnyBrowser && kind != c.kind {
+			continue
+		}
+		if path := findExecutable(c.paths(), c.names); path != "" {
+			return newBrowser(c.kind, path, profileDir)
+		}
+	}
+	return nil
+}
+
+// chromiumArgs returns the command line arguments opening url
+// as an app window of a Chromium-based browser.
+func chromiumArgs(url string, config *windowConfig) []string {
+	args := []string{
+		"--app=" + url,
+		fmt.Sprintf("--window-size=%d,%d", config.width, config.height),
+	}
+	if config.positioned {
+		args = append(args, fmt.Sprintf("--window-position=%d,%d", config.x, config.y))
+	}
+	return args
+}
+
+// launcher starts the processes of a browser using a dedicated profile.
+// The process started first is the one whose lifetime is tracked;
+// later invocations hand their window over to it and exit.
+type launcher struct {
+	execPath   string
+	profileDir string
+	mu         sync.Mutex
+	main       *process
+	pages      []string
+}
+
+// page writes a page redirecting to u and returns its file URL, which is
+// passed to the browser instead of u. u holds the token of the App, which
+// must not show in the command line of the browser since other users can
+// read it. The page is private to the user since it's created with mode
+// 0600, in the profile directory whose mode is 0700.
+func (l *launcher) page(u string) (string, error) {
+	f, err := os.CreateTemp(l.profileDir, "oden-open-*.html")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a launch page: %w", err)
 	}
+	l.mu.Lock()
+	l.pages = append(l.pages, f.Name())
+	l.mu.Unlock()
 
-	edge := detectEdge()
-	if edge != nil {
-		return edge
+	_, err = fmt.Fprintf(
+		f,
+		"<!DOCTYPE html><meta http-equiv=\"refresh\" content=\"0; url=%s\">",
+		html.EscapeString(u),
+	)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write a launch page: %w", err)
 	}
 
-	chromium := detectChromium()
-	if chromium != nil {
-		return chromium
+	path := filepath.ToSlash(f.Name())
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // a Windows path such as C:/...
 	}
+	return (&url.URL{Scheme: "file", Path: path}).String(), nil
+}
 
-	firefox := detectFirefox()
-	if firefox != nil {
-		return firefox
+func (l *launcher) launched() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.main != nil
+}
+
+func (l *launcher) launch(args ...string) error {
+	p, err := startProcess(l.execPath, args...)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", l.execPath, err)
 	}
 
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.main == nil {
+		l.main = p
+	}
 	return nil
 }
 
+func (l *launcher) wait() {
+	l.mu.Lock()
+	p := l.main
+	l.mu.Unlock()
 
-type chrome struct {
-	execPath string
-	cmd      *exec.Cmd
+	if p != nil {
+		p.wait()
+	}
 }
 
-func (c *chrome) open(_ string, port, width, height int) {
-	cmd := exec.Command(
-		c.execPath,
-		fmt.Sprintf("--app=http://localhost:%d", port),
-		fmt.Sprintf("--window-size=%d,%d", width, height),
-	)
-	cmd.Start()
-	c.cmd = cmd
+func (l *launcher) terminate() {
+	l.mu.Lock()
+	p := l.main
+	l.mu.Unlock()
+
+	if p != nil {
+		p.terminate()
+	}
+
+	l.mu.Lock()
+	pages := l.pages
+	l.pages = nil
+	l.mu.Unlock()
+	for _, page := range pages {
+		os.Remove(page)
+	}
+}
+
+// chromiumBased is a Chromium-based browser such as Chrome, Edge, Chromium, Brave or Vivaldi.
+type chromiumBased struct {
+	launcher
+}
+
+func (c *chromiumBased) open(url string, config *windowConfig) error {
+	args, err := c.args(url, config)
+	if err != nil {
+		return err
+	}
+	return c.launch(args...)
+}
+
+// args returns the command line arguments opening url.
+func (c *chromiumBased) args(url string, config *windowConfig) ([]string, error) {
+	page, err := c.page(url)
+	if err != nil {
+		return nil, err
+	}
+	args := chromiumArgs(page, config)
+	if c.profileDir != "" {
+		args = append(
+			args,
+			"--user-data-dir="+c.profileDir,
+			"--no-first-run",
+			"--no-default-browser-check",
+		)
+	}
+	return args, nil
 }
 
-func detectChrome() *chrome {
-	var paths []string
+type firefox struct {
+	launcher
+}
+
+func (f *firefox) open(url string, _ *windowConfig) error {
+	args, err := f.args(url)
+	if err != nil {
+		return err
+	}
+	return f.launch(args...)
+}
+
+// args returns the command line arguments opening url.
+func (f *firefox) args(url string) ([]string, error) {
+	page, err := f.page(url)
+	if err != nil {
+		return nil, err
+	}
+	if f.profileDir == "" {
+		return []string{"-no-remote", "-private-window", page, "-foreground"}, nil
+	}
+	if f.launched() {
+		return []string{"-profile", f.profileDir, "-new-window", page}, nil
+	}
+	return []string{"-profile", f.profileDir, "-new-instance", page, "-foreground"}, nil
+}
+
+func newBrowser(kind BrowserKind, execPath, profileDir string) browser {
+	if kind == Firefox {
+		return &firefox{
+			launcher: launcher{
+				execPath:   execPath,
+				profileDir: profileDir,
+			},
+		}
+	}
+	return &chromiumBased{
+		launcher: launcher{
+			execPath:   execPath,
+			profileDir: profileDir,
+		},
+	}
+}
+
+// guessBrowserKind guesses the kind of browser from the name of its executable.
+// Unknown executables are assumed to be Chromium-based.
+func guessBrowserKind(execPath string) BrowserKind {
+	name := strings.ToLower(filepath.Base(execPath))
+	switch {
+	case strings.Contains(name, "firefox"):
+		return Firefox
+	case strings.Contains(name, "edge"):
+		return Edge
+	case strings.Contains(name, "brave"):
+		return Brave
+	case strings.Contains(name, "vivaldi"):
+		return Vivaldi
+	case strings.Contains(name, "chromium"):
+		return Chromium
+	}
+	return Chrome
+}
+
+// findExecutable returns the first of paths that exists,
+// or the first of names found in the PATH environment variable.
+func findExecutable(paths []string, names []string) string {
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	for _, name := range names {
+		if path, err := exec.LookPath(name); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// flatpakPaths returns the paths of the launchers of a Flatpak application
+// installed system-wide or for the current user.
+func flatpakPaths(appID string) []string {
+	paths := []string{
+		"/var/lib/flatpak/exports/bin/" + appID,
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, home+"/.local/share/flatpak/exports/bin/"+appID)
+	}
+	return paths
+}
+
+func chromePaths() []string {
 	switch runtime.GOOS {
 	case "darwin":
-		paths = []string{
+		return []string{
 			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
 			"/Applications/Google Chrome Canary.app/Contents/MacOS/Google Chrome Canary",
-			"/Applications/Microsoft Edge.app/Contents/MacOS/Microsoft Edge",
 			"/usr/bin/google-chrome-stable",
 			"/usr/bin/google-chrome",
 		}
 	case "windows":
-		paths = []string{
+		return []string{
 			os.Getenv("LocalAppData") + "/Google/Chrome/Application/chrome.exe",
 			os.Getenv("ProgramFiles") + "/Google/Chrome/Application/chrome.exe",
 			os.Getenv("ProgramFiles(x86)") + "/Google/Chrome/Application/chrome.exe",
-			os.Getenv("ProgramFiles(x86)") + "/Microsoft/Edge/Application/msedge.exe",
-			os.Getenv("ProgramFiles") + "/Microsoft/Edge/Application/msedge.exe",
-		}
-	default:
-		paths = []string{
-			"/usr/bin/google-chrome-stable",
-			"/usr/bin/google-chrome",
 		}
 	}
-
-	for _, path := range paths {
-		if _, err := os.Stat(path); os.IsNotExist(err) {
-			continue
-		}
-		return &chrome{
-			execPath: path,
-		}
-	}
-	return nil
-}
-
-type edge struct {
-	execPath string
-	cmd      *exec.Cmd
+	return append([]string{
+		"/usr/bin/google-chrome-stable",
+		"/usr/bin/google-chrome",
+		"/opt/google/chrome/google-chrome",
+	}, flatpakPaths("com.google.Chrome")...)
 }
 
-func (e *edge) open(_ string, port, width, height int) {
-	cmd := exec.Command(
-		e.execPath,
-		fmt.Sprintf("--app=http://localhost:%d", port),
-		fmt.Sprintf("--window-size=%d,%d", width, height),
-	)
-	cmd.Start()
-	e.cmd = cmd
-}
-
-func detectEdge() *edge {
-	var paths []string
+func edgePaths() []string {
 	switch runtime.GOOS {
 	case "darwin":
-		paths = []string{
+		return []string{
 			"/Applications/Microsoft Edge.app/Contents/MacOS/Microsoft Edge",
 		}
 	case "windows":
-		paths = []string{
+		return []string{
 			os.Getenv("ProgramFiles(x86)") + "/Microsoft/Edge/Application/msedge.exe",
 			os.Getenv("ProgramFiles") + "/Microsoft/Edge/Application/msedge.exe",
 		}
-	default:
-		paths = []string{}
-	}
-
-	for _, path := range paths {
-		if _, err := os.Stat(path); os.IsNotExist(err) {
-			continue
-		}
-		return &edge{
-			execPath: path,
-		}
 	}
-	return nil
-}
-
-type chromium struct {
-	execPath string
-	cmd      *exec.Cmd
+	return append([]string{
+		"/usr/bin/microsoft-edge-stable",
+		"/usr/bin/microsoft-edge",
+		"/opt/microsoft/msedge/msedge",
+	}, flatpakPaths("com.microsoft.Edge")...)
 }
 
-func (c *chromium) Open(_ string, port, width, height int) {
-	cmd := exec.Command(
-		c.execPath,
-		fmt.Sprintf("--app=http://localhost:%d", port),
-		fmt.Sprintf("--window-size=%d,%d", width, height),
-	)
-	cmd.Start()
-	c.cmd = cmd
-}
-
-func detectChromium() *chrome {
-	var paths []string
+func chromiumPaths() []string {
 	switch runtime.GOOS {
 	case "darwin":
-		paths = []string{
+		return []string{
 			"/Applications/Chromium.app/Contents/MacOS/Chromium",
 			"/usr/bin/chromium",
 			"/usr/bin/chromium-browser",
 		}
 	case "windows":
-		paths = []string{
+		return []string{
 			os.Getenv("LocalAppData") + "/Chromium/Application/chrome.exe",
 			os.Getenv("ProgramFiles") + "/Chromium/Application/chrome.exe",
 			os.Getenv("ProgramFiles(x86)") + "/Chromium/Application/chrome.exe",
 		}
-	default:
-		paths = []string{
-			"/usr/bin/chromium",
-			"/usr/bin/chromium-browser",
-			"/snap/bin/chromium",
-		}
 	}
+	return append([]string{
+		"/usr/bin/chromium",
+		"/usr/bin/chromium-browser",
+		"/snap/bin/chromium",
+		"/opt/chromium/chrome",
+	}, flatpakPaths("org.chromium.Chromium")...)
+}
 
-	for _, path := range paths {
-		if _, err := os.Stat(path); os.IsNotExist(err) {
-			continue
+func bravePaths() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{
+			"/Applications/Brave Browser.app/Contents/MacOS/Brave Browser",
 		}
-		return &chrome{
-			execPath: path,
+	case "windows":
+		return []string{
+			os.Getenv("LocalAppData") + "/BraveSoftware/Brave-Browser/Application/brave.exe",
+			os.Getenv("ProgramFiles") + "/BraveSoftware/Brave-Browser/Application/brave.exe",
+			os.Getenv("ProgramFiles(x86)") + "/BraveSoftware/Brave-Browser/Application/brave.exe",
 		}
 	}
-	return nil
-}
-
-type firefox struct {
-	execPath string
-	cmd      *exec.Cmd
+	return append([]string{
+		"/usr/bin/brave-browser",
+		"/snap/bin/brave",
+		"/opt/brave.com/brave/brave-browser",
+	}, flatpakPaths("com.brave.Browser")...)
 }
 
-func (f *firefox) open(_ string, port, width, height int) {
-	cmd := exec.Command(
-		f.execPath,
-		"-no-remote",
-		"-private-window",
-		fmt.Sprintf("http://localhost:%d", port),
-		"-foreground",
-	)
-	cmd.Start()
-	f.cmd = cmd
+func vivaldiPaths() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{
+			"/Applications/Vivaldi.app/Contents/MacOS/Vivaldi",
+		}
+	case "windows":
+		return []string{
+			os.Getenv("LocalAppData") + "/Vivaldi/Application/vivaldi.exe",
+			os.Getenv("ProgramFiles") + "/Vivaldi/Application/vivaldi.exe",
+		}
+	}
+	return append([]string{
+		"/usr/bin/vivaldi-stable",
+		"/usr/bin/vivaldi",
+		"/opt/vivaldi/vivaldi",
+	}, flatpakPaths("com.vivaldi.Vivaldi")...)
 }
 
-func detectFirefox() *firefox {
-	var paths []string
+func firefoxPaths() []string {
 	switch runtime.GOOS {
 	case "darwin":
-		paths = []string{
-			"/Applications/Chromium.app/Contents/MacOS/Firefox",
+		return []string{
+			"/Applications/Firefox.app/Contents/MacOS/firefox",
 			"/usr/bin/firefox",
 		}
 	case "windows":
-		paths = []string{
+		return []string{
 			os.Getenv("LocalAppData") + "/Mozilla Firefox/firefox.exe",
 			os.Getenv("ProgramFiles") + "/Mozilla Firefox/firefox.exe",
 			os.Getenv("ProgramFiles(x86)") + "/Mozilla Firefox/firefox.exe",
 		}
-	default:
-		paths = []string{
-			"/usr/bin/firefox",
-			"/snap/bin/firefox",
-		}
 	}
-
-	for _, path := range paths {
-		if _, err := os.Stat(path); os.IsNotExist(err) {
-			continue
-		}
-		return &firefox{
-			execPath: path,
-		}
-	}
-	return nil
+	return append([]string{
+		"/usr/bin/firefox",
+		"/snap/bin/firefox",
+		"/opt/firefox/firefox",
+	}, flatpakPaths("org.mozilla.firefox")...)
 }
@@ -0,0 +1,257 @@
+package core
+
+import (
+	"html"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseBrowserKind(t *testing.T) {
+	tests := []struct {
+		s    string
+		want BrowserKind
+		ok   bool
+	}{
+		{"any", AnyBrowser, true},
+		{"webview2", WebView2, true},
+		{"chrome", Chrome, true},
+		{"Edge", Edge, true},
+		{"CHROMIUM", Chromium, true},
+		{"firefox", Firefox, true},
+		{"brave", Brave, true},
+		{"vivaldi", Vivaldi, true},
+		{"", AnyBrowser, false},
+		{"safari", AnyBrowser, false},
+		{"/usr/bin/firefox", AnyBrowser, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseBrowserKind(tt.s)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseBrowserKind(%q) = %v, %v, want %v, %v", tt.s, got, ok, tt.want, tt.ok)
+		}
+	}
+	for k := AnyBrowser; k <= Vivaldi; k++ {
+		if got, ok := ParseBrowserKind(k.String()); got != k || !ok {
+			t.Errorf("ParseBrowserKind(%q) = %v, %v, want %v", k, got, ok, k)
+		}
+	}
+}
+
+func TestGuessBrowserKind(t *testing.T) {
+	tests := []struct {
+		path string
+		want BrowserKind
+	}{
+		{"/usr/bin/firefox", Firefox},
+		{"/Applications/Firefox.app/Contents/MacOS/firefox", Firefox},
+		{`C:\Program Files\Mozilla Firefox\firefox.exe`, Firefox},
+		{"/usr/bin/microsoft-edge-stable", Edge},
+		{`C:\Program Files (x86)\Microsoft\Edge\Application\msedge.exe`, Edge},
+		{"/usr/bin/brave-browser", Brave},
+		{"/usr/bin/vivaldi-stable", Vivaldi},
+		{"/usr/bin/chromium-browser", Chromium},
+		{"/usr/bin/google-chrome-stable", Chrome},
+		// Unknown executables are assumed to be Chromium-based.
+		{"/opt/thorium/thorium", Chrome},
+		// Only the name of the executable is considered.
+		{"/home/firefox/bin/chromium", Chromium},
+	}
+	for _, tt := range tests {
+		path := filepath.FromSlash(tt.path)
+		if got := guessBrowserKind(path); got != tt.want {
+			t.Errorf("guessBrowserKind(%q) = %v, want %v", path, got, tt.want)
+		}
+	}
+}
+
+// describeBrowser returns the kind and the executable of a browser
+// started by a launcher.
+func describeBrowser(b browser) string {
+	switch b := b.(type) {
+	case *firefox:
+		return "firefox " + b.execPath
+	case *chromiumBased:
+		return "chromium-based " + b.execPath
+	case nil:
+		return "none"
+	}
+	return "unexpected"
+}
+
+func TestSelectBrowser(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake browsers aren't executable on Windows")
+	}
+	dir := t.TempDir()
+	fake := func(name string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	firefoxPath := fake("firefox")
+	bravePath := fake("brave-browser")
+	customPath := fake("my-browser")
+	missingPath := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		name    string
+		options []func(*AppOptions)
+		env     string
+		want    string
+	}{
+		{
+			"guessed kind",
+			[]func(*AppOptions){WithBrowserExecutable(AnyBrowser, firefoxPath)},
+			"",
+			"firefox " + firefoxPath,
+		},
+		{
+			"given kind",
+			[]func(*AppOptions){WithBrowserExecutable(Firefox, customPath)},
+			"",
+			"firefox " + customPath,
+		},
+		{
+			"unknown executable",
+			[]func(*AppOptions){WithBrowserExecutable(AnyBrowser, customPath)},
+			"",
+			"chromium-based " + customPath,
+		},
+		{
+			"missing executable",
+			[]func(*AppOptions){WithBrowserExecutable(Chrome, missingPath)},
+			"",
+			"none",
+		},
+		{
+			"executable in the environment",
+			[]func(*AppOptions){WithBrowserExecutable(Firefox, firefoxPath)},
+			bravePath,
+			"chromium-based " + bravePath,
+		},
+		{
+			"missing executable in the environment",
+			[]func(*AppOptions){WithBrowserExecutable(Firefox, firefoxPath)},
+			missingPath,
+			"none",
+		},
+		{
+			"kind in the environment",
+			[]func(*AppOptions){WithBrowserExecutable(Chrome, bravePath)},
+			"FireFox",
+			"firefox",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(BrowserEnv, tt.env)
+			// Firefox is found in the PATH unless it's installed.
+			t.Setenv("PATH", dir)
+			o := defaultAppOptions()
+			for _, option := range tt.options {
+				option(o)
+			}
+
+			got := describeBrowser(selectBrowser(o, t.TempDir()))
+			if tt.want == "firefox" {
+				if !strings.HasPrefix(got, "firefox ") {
+					t.Errorf("selected %s, want firefox", got)
+				}
+			} else if got != tt.want {
+				t.Errorf("selected %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBrowserArgsHideToken(t *testing.T) {
+	const token = "s3cr3t-token"
+	target := "http://127.0.0.1:8080/?token=" + token + "&window=1"
+	config := &windowConfig{width: 800, height: 600}
+
+	tests := []struct {
+		name string
+		args func(profileDir string) ([]string, error)
+	}{
+		{"chromium", func(dir string) ([]string, error) {
+			b := newBrowser(Chrome, "chrome", dir).(*chromiumBased)
+			return b.args(target, config)
+		}},
+		{"firefox", func(dir string) ([]string, error) {
+			b := newBrowser(Firefox, "firefox", dir).(*firefox)
+			return b.args(target)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			args, err := tt.args(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var page string
+			for _, arg := range args {
+				if strings.Contains(arg, token) {
+					t.Errorf("argument %q contains the token", arg)
+				}
+				if i := strings.Index(arg, "file://"); i >= 0 {
+					page = arg[i:]
+				}
+			}
+			if page == "" {
+				t.Fatalf("no launch page in %q", args)
+			}
+
+			u, err := url.Parse(page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := u.Path
+			if runtime.GOOS == "windows" {
+				path = strings.TrimPrefix(path, "/")
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if runtime.GOOS != "windows" && info.Mode().Perm() != 0o600 {
+				t.Errorf("mode of the launch page = %v, want 0600", info.Mode().Perm())
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(b), html.EscapeString(target)) {
+				t.Errorf("launch page %q doesn't redirect to %q", b, target)
+			}
+		})
+	}
+}
+
+func TestLauncherRemovesPages(t *testing.T) {
+	l := &launcher{profileDir: t.TempDir()}
+	page, err := l.page("http://127.0.0.1:8080/?token=x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := u.Path
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/")
+	}
+
+	l.terminate()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("launch page still exists after terminate: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// connQueueSize is the number of messages a Conn can hold before they are received.
+const connQueueSize = 4096
+
+// Conn is an in-memory connection to a window. It receives the same messages
+// as the pages shown in a browser, and dispatches events to the App as if a
+// page sent them. It allows driving an App without a browser, e.g. in tests;
+// see the odentest package.
+type Conn struct {
+	app *App
+	win *Window
+	c   *client
+}
+
+// Connect returns a new Conn to the window. The first message received
+// through the Conn contains the whole view of the window.
+func (win *Window) Connect() *Conn {
+	c := win.hub.add(nil, connQueueSize)
+	win.app.call(func() {
+		c.msgs <- win.syncMessage()
+	})
+	return &Conn{
+		app: win.app,
+		win: win,
+		c:   c,
+	}
+}
+
+// Receive returns the next message sent to the window,
+// or false if no message is waiting to be received.
+func (conn *Conn) Receive() (string, bool) {
+	select {
+	case msg := <-conn.c.msgs:
+		return msg, true
+	default:
+		return "", false
+	}
+}
+
+// Dispatch sends an event to the App as if the page sent it,
+// and waits for the handlers of the event to return.
+// The event goes through the same JSON encoding as the events of a page,
+// so that e.g. numbers in props are received as float64.
+func (conn *Conn) Dispatch(target WidgetID, event string, props map[string]interface{}) error {
+	return conn.DispatchDetail(target, event, props, EventDetail{})
+}
+
+// DispatchDetail is like Dispatch but also sends the details of the event,
+// e.g. the key of a keyboard event.
+func (conn *Conn) DispatchDetail(target WidgetID, event string, props map[string]interface{}, detail EventDetail) error {
+	b, err := json.Marshal(props)
+	if err != nil {
+		return err
+	}
+	ev := &rawEvent{
+		Target:    strconv.Itoa(int(target)),
+		EventName: event,
+		Detail:    detail,
+	}
+	if err := json.Unmarshal(b, &ev.Props); err != nil {
+		return err
+	}
+	if ev.Props == nil {
+		ev.Props = map[string]interface{}{}
+	}
+	conn.app.call(func() {
+		conn.app.handleEvent(ev)
+	})
+	return nil
+}
+
+// Shortcut sends the keys of accelerator to the App as if they were pressed
+// in the page. See App.AddShortcut for the syntax of accelerators.
+func (conn *Conn) Shortcut(accelerator string) error {
+	s, err := parseShortcut(accelerator)
+	if err != nil {
+		return err
+	}
+	ev := &rawEvent{
+		EventName: "shortcut",
+		Props:     map[string]interface{}{},
+		Detail: EventDetail{
+			Key:       s.Key,
+			Modifiers: s.Modifiers,
+		},
+		Shortcut: s.ID,
+	}
+	conn.app.call(func() {
+		conn.app.handleEvent(ev)
+	})
+	return nil
+}
+
+// Close disconnects the Conn from the window.
+func (conn *Conn) Close() {
+	conn.win.hub.remove(conn.c)
+}
@@ -0,0 +1,236 @@
+package core
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// dispatcher runs the functions dispatched to the UI goroutine one at a time,
+// in the order they are dispatched. There is one UI goroutine for all the Apps
+// of the process, so that widgets may be shared by Apps and a handler of an App
+// may call the functions of another one, e.g. to prompt the user in one of its
+// windows.
+type dispatcher struct {
+	mu     sync.Mutex
+	tasks  []*task
+	wakeup chan struct{}
+	// running is the number of functions being run, more than one when
+	// a function runs the others by Wait, and busy the number of functions
+	// of each App among them.
+	running int
+	busy    map[*App]int
+	// idle is signalled when a function returns.
+	idle *sync.Cond
+	// orphans serializes the functions of call run after their App quit.
+	orphans sync.Mutex
+}
+
+// task is a function dispatched to the UI goroutine by an App.
+type task struct {
+	app   *App
+	f     func()
+	state taskState
+}
+
+type taskState int
+
+const (
+	taskQueued taskState = iota
+	taskRunning
+	taskDone
+)
+
+var (
+	ui      = newDispatcher()
+	startUI sync.Once
+)
+
+func newDispatcher() *dispatcher {
+	d := &dispatcher{
+		wakeup: make(chan struct{}, 1),
+		busy:   make(map[*App]int),
+	}
+	d.idle = sync.NewCond(&d.mu)
+	return d
+}
+
+// Dispatch runs f on the UI goroutine, after the functions dispatched before.
+// Event handlers are run on the UI goroutine, and so are the renderings of
+// widgets, so models shown by widgets must only be changed there: a goroutine
+// updating a model, e.g. on a timer, has to do so through Dispatch.
+// Dispatch doesn't wait for f to run. f is not run if the App quits first.
+func (app *App) Dispatch(f func()) {
+	app.dispatch(f)
+}
+
+func (app *App) dispatch(f func()) *task {
+	startUI.Do(func() {
+		go ui.loop()
+	})
+
+	t := &task{app: app, f: f}
+	ui.mu.Lock()
+	ui.tasks = append(ui.tasks, t)
+	ui.mu.Unlock()
+
+	select {
+	case ui.wakeup <- struct{}{}:
+	default:
+	}
+	return t
+}
+
+// call runs f on the UI goroutine and waits for it to return.
+// f is run directly if call is called on the UI goroutine. If the App quits
+// before running f, f is run once the functions of the App being run return.
+func (app *App) call(f func()) {
+	if onUIGoroutine() {
+		f()
+		return
+	}
+
+	done := make(chan struct{})
+	t := app.dispatch(func() {
+		defer close(done)
+		f()
+	})
+	select {
+	case <-done:
+		return
+	case <-app.ctx.Done():
+	}
+
+	// The functions of the App aren't run anymore, apart from the ones
+	// already running.
+	d := ui
+	d.mu.Lock()
+	for t.state == taskRunning || t.state == taskQueued && d.busy[app] > 0 {
+		d.idle.Wait()
+	}
+	orphan := t.state == taskQueued
+	t.state = taskDone
+	d.mu.Unlock()
+	if orphan {
+		d.orphans.Lock()
+		defer d.orphans.Unlock()
+		f()
+	}
+}
+
+// runTask runs a dispatched function. Its frame marks the UI goroutine
+// while the function runs; see onUIGoroutine.
+//
+//go:noinline
+func runTask(f func()) {
+	f()
+}
+
+var runTaskEntry = reflect.ValueOf(runTask).Pointer()
+
+// onUIGoroutine reports whether the caller is run by a dispatched function,
+// i.e. whether runTask is on the stack of the goroutine. Only the UI goroutine
+// calls runTask.
+func onUIGoroutine() bool {
+	ui.mu.Lock()
+	running := ui.running > 0
+	ui.mu.Unlock()
+	if !running {
+		return false
+	}
+
+	pcs := make([]uintptr, 32)
+	for {
+		n := runtime.Callers(2, pcs)
+		if n < len(pcs) {
+			pcs = pcs[:n]
+			break
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+	for _, pc := range pcs {
+		if f := runtime.FuncForPC(pc - 1); f != nil && f.Entry() == runTaskEntry {
+			return true
+		}
+	}
+	return false
+}
+
+// loop runs the dispatched functions. It is the UI goroutine.
+func (d *dispatcher) loop() {
+	for range d.wakeup {
+		d.runTasks()
+	}
+}
+
+// runTasks runs the dispatched functions until none is left,
+// skipping the ones of the Apps which quit.
+func (d *dispatcher) runTasks() {
+	for {
+		d.mu.Lock()
+		if len(d.tasks) == 0 {
+			d.mu.Unlock()
+			return
+		}
+		t := d.tasks[0]
+		d.tasks[0] = nil
+		d.tasks = d.tasks[1:]
+		if t.state != taskQueued || t.app.ctx.Err() != nil {
+			d.mu.Unlock()
+			continue
+		}
+		t.state = taskRunning
+		d.running++
+		d.busy[t.app]++
+		d.mu.Unlock()
+
+		runTask(t.f)
+
+		d.mu.Lock()
+		t.state = taskDone
+		d.running--
+		if d.busy[t.app]--; d.busy[t.app] == 0 {
+			delete(d.busy, t.app)
+		}
+		d.idle.Broadcast()
+		d.mu.Unlock()
+	}
+}
+
+// Wait blocks until done is closed or the App quits.
+// Called on the UI goroutine, e.g. by an event handler, Wait keeps running
+// the dispatched functions and sending the updates meanwhile, so that the
+// Apps stay responsive while the handler waits for the user to answer
+// a dialog. The updates posted by the handler so far are sent too.
+func (app *App) Wait(done <-chan struct{}) {
+	if !onUIGoroutine() {
+		select {
+		case <-done:
+		case <-app.ctx.Done():
+		}
+		return
+	}
+
+	q := app.updates
+	q.mu.Lock()
+	depth := q.depth
+	q.depth = 0
+	q.mu.Unlock()
+	app.scheduleFlush()
+	defer func() {
+		q.mu.Lock()
+		q.depth += depth
+		q.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-app.ctx.Done():
+			return
+		case <-ui.wakeup:
+			ui.runTasks()
+		}
+	}
+}
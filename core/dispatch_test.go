@@ -0,0 +1,224 @@
+package core
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// dispatchTestView is an empty view of the Apps of the dispatch tests.
+type dispatchTestView struct {
+	id WidgetID
+}
+
+func (v *dispatchTestView) ID() WidgetID {
+	return v.id
+}
+
+func (v *dispatchTestView) View() string {
+	return fmt.Sprintf(`<div id="%s"></div>`, v.id)
+}
+
+func (v *dispatchTestView) Attach(app *App) {
+	v.id = app.IDs().Next()
+}
+
+// newDispatchTestApp returns an App which quits when the test finishes.
+func newDispatchTestApp(t *testing.T) *App {
+	t.Helper()
+
+	app := NewApp(t.Name(), &dispatchTestView{})
+	t.Cleanup(app.MainWindow().Close)
+	return app
+}
+
+func TestDispatchFromGoroutines(t *testing.T) {
+	app := newDispatchTestApp(t)
+
+	// count is only accessed on the UI goroutine, which the race detector checks.
+	count := 0
+	const goroutines, tasks = 8, 50
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < tasks; j++ {
+				if i%2 == 0 {
+					app.call(func() { count++ })
+				} else {
+					app.Dispatch(func() { count++ })
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var got int
+	app.call(func() { got = count })
+	if got != goroutines*tasks {
+		t.Errorf("count = %d, want %d", got, goroutines*tasks)
+	}
+}
+
+func TestOnUIGoroutine(t *testing.T) {
+	app := newDispatchTestApp(t)
+
+	var onUI, onOther bool
+	app.call(func() {
+		onUI = onUIGoroutine()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			onOther = onUIGoroutine()
+		}()
+		<-done
+	})
+	if !onUI || onOther {
+		t.Errorf("onUIGoroutine() = %v in a dispatched function and %v in another goroutine, want true and false", onUI, onOther)
+	}
+	if onUIGoroutine() {
+		t.Error("onUIGoroutine() = true in the test goroutine")
+	}
+}
+
+func TestCallAfterQuit(t *testing.T) {
+	app := newDispatchTestApp(t)
+
+	var running, queued int
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		app.call(func() {
+			close(started)
+			<-release
+			running++
+		})
+	}()
+	<-started
+	go func() {
+		defer wg.Done()
+		app.call(func() {
+			queued++
+		})
+	}()
+	for !queuedBy(app) {
+		runtime.Gosched()
+	}
+
+	app.cancel()
+	close(release)
+	wg.Wait()
+	// The running function isn't run again, and the queued one is run
+	// once the running one has returned.
+	if running != 1 || queued != 1 {
+		t.Errorf("functions run %d and %d times, want once", running, queued)
+	}
+}
+
+// queuedBy reports whether a function dispatched by app is waiting to be run.
+func queuedBy(app *App) bool {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	for _, t := range ui.tasks {
+		if t.app == app && t.state == taskQueued {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAppsShareUIGoroutine(t *testing.T) {
+	a := newDispatchTestApp(t)
+	b := newDispatchTestApp(t)
+
+	// count is only accessed on the UI goroutine, which the race detector checks.
+	count := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				b.Dispatch(func() { count++ })
+				a.call(func() {
+					// A handler of a calls b, which is run right away.
+					var inB bool
+					b.call(func() {
+						inB = onUIGoroutine()
+						count++
+					})
+					if !inB {
+						t.Error("function of b not run on the UI goroutine")
+					}
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	var got int
+	b.call(func() { got = count })
+	if got != 4*20*2 {
+		t.Errorf("count = %d, want %d", got, 4*20*2)
+	}
+}
+
+func TestWait(t *testing.T) {
+	a := newDispatchTestApp(t)
+	b := newDispatchTestApp(t)
+
+	// count is only accessed on the UI goroutine, which the race detector checks.
+	count := 0
+	const goroutines, tasks = 8, 10
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < tasks; j++ {
+				answered := make(chan struct{})
+				switch i % 3 {
+				case 0:
+					// A handler waiting for another goroutine keeps the
+					// dispatched functions running.
+					a.call(func() {
+						go a.Dispatch(func() {
+							count++
+							close(answered)
+						})
+						a.Wait(answered)
+					})
+				case 1:
+					// Waiting on another goroutine just blocks.
+					a.Dispatch(func() {
+						count++
+						close(answered)
+					})
+					a.Wait(answered)
+				case 2:
+					// A handler of a may wait for b to answer.
+					a.call(func() {
+						b.Dispatch(func() {
+							count++
+							close(answered)
+						})
+						b.Wait(answered)
+					})
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var got int
+	a.call(func() { got = count })
+	if got != goroutines*tasks {
+		t.Errorf("count = %d, want %d", got, goroutines*tasks)
+	}
+}
@@ -0,0 +1,192 @@
+package core
+
+import "fmt"
+
+// Modifiers is a set of modifier keys held down during an event.
+type Modifiers int
+
+const (
+	AltKey Modifiers = 1 << iota
+	CtrlKey
+	ShiftKey
+	MetaKey
+)
+
+// Has reports whether all the keys in m2 are in m.
+func (m Modifiers) Has(m2 Modifiers) bool {
+	return m&m2 == m2
+}
+
+// MouseButton is a button of a mouse, numbered as in the DOM.
+type MouseButton int
+
+const (
+	LeftButton MouseButton = iota
+	MiddleButton
+	RightButton
+	BackButton
+	ForwardButton
+)
+
+// EventDetail holds the properties of a DOM event sent along with it.
+// Key properties are set for keyboard events, and mouse properties
+// for mouse events; modifiers are set for both.
+type EventDetail struct {
+	Key       string      `json:"key,omitempty"`
+	Code      string      `json:"code,omitempty"`
+	Repeat    bool        `json:"repeat,omitempty"`
+	Modifiers Modifiers   `json:"modifiers,omitempty"`
+	ClientX   float64     `json:"clientX,omitempty"`
+	ClientY   float64     `json:"clientY,omitempty"`
+	OffsetX   float64     `json:"offsetX,omitempty"`
+	OffsetY   float64     `json:"offsetY,omitempty"`
+	Button    MouseButton `json:"button,omitempty"`
+	Buttons   int         `json:"buttons,omitempty"`
+}
+
+type rawEvent struct {
+	Target    string                 `json:"target"`
+	EventName string                 `json:"event"`
+	Props     map[string]interface{} `json:"props"`
+	Detail    EventDetail            `json:"detail"`
+	Shortcut  string                 `json:"shortcut,omitempty"`
+}
+
+// Event is a DOM event sent by a page for a widget.
+type Event interface {
+	ID() string
+	Target() Widget
+	EventName() string
+
+	// Props returns the properties of the event target and the fields of
+	// the event detail requested by the TargetEvent of the event.
+	Props() map[string]interface{}
+	// StringProp returns the property of the event target with the given name
+	// if it is a string.
+	StringProp(name string) (string, bool)
+	// BoolProp returns the property of the event target with the given name
+	// if it is a boolean.
+	BoolProp(name string) (bool, bool)
+	// NumberProp returns the property of the event target with the given name
+	// if it is a number.
+	NumberProp(name string) (float64, bool)
+	// StringsProp returns the property of the event target with the given name
+	// if it is an array of strings, such as the value of a multiple select.
+	StringsProp(name string) ([]string, bool)
+
+	// Key returns the key value of a keyboard event, e.g. "a" or "Enter".
+	Key() string
+	// Code returns the physical key of a keyboard event, e.g. "KeyA".
+	Code() string
+	// Repeat reports whether a keyboard event is repeated by holding the key down.
+	Repeat() bool
+	// Modifiers returns the modifier keys held down during a keyboard or mouse event.
+	Modifiers() Modifiers
+
+	// ClientX and ClientY return the position of a mouse event in the window.
+	ClientX() float64
+	ClientY() float64
+	// OffsetX and OffsetY return the position of a mouse event in the target.
+	OffsetX() float64
+	OffsetY() float64
+	// Button returns the button pressed or released in a mouse event.
+	Button() MouseButton
+	// Buttons returns the buttons held down during a mouse event,
+	// with bit n set for the button n.
+	Buttons() int
+}
+
+type actualEvent struct {
+	target    Widget
+	eventName string
+	props     map[string]interface{}
+	detail    EventDetail
+}
+
+func (e *actualEvent) ID() string {
+	if e.target == nil {
+		return e.eventName
+	}
+	return fmt.Sprintf("%s.%s", e.target.ID(), e.eventName)
+}
+
+func (e *actualEvent) Target() Widget {
+	return e.target
+}
+
+func (e *actualEvent) EventName() string {
+	return e.eventName
+}
+
+func (e *actualEvent) Props() map[string]interface{} {
+	return e.props
+}
+
+func (e *actualEvent) StringProp(name string) (string, bool) {
+	v, ok := e.props[name].(string)
+	return v, ok
+}
+
+func (e *actualEvent) BoolProp(name string) (bool, bool) {
+	v, ok := e.props[name].(bool)
+	return v, ok
+}
+
+func (e *actualEvent) NumberProp(name string) (float64, bool) {
+	v, ok := e.props[name].(float64)
+	return v, ok
+}
+
+func (e *actualEvent) StringsProp(name string) ([]string, bool) {
+	a, ok := e.props[name].([]interface{})
+	if !ok {
+		return nil, false
+	}
+	v := make([]string, len(a))
+	for i, x := range a {
+		if v[i], ok = x.(string); !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func (e *actualEvent) Key() string {
+	return e.detail.Key
+}
+
+func (e *actualEvent) Code() string {
+	return e.detail.Code
+}
+
+func (e *actualEvent) Repeat() bool {
+	return e.detail.Repeat
+}
+
+func (e *actualEvent) Modifiers() Modifiers {
+	return e.detail.Modifiers
+}
+
+func (e *actualEvent) ClientX() float64 {
+	return e.detail.ClientX
+}
+
+func (e *actualEvent) ClientY() float64 {
+	return e.detail.ClientY
+}
+
+func (e *actualEvent) OffsetX() float64 {
+	return e.detail.OffsetX
+}
+
+func (e *actualEvent) OffsetY() float64 {
+	return e.detail.OffsetY
+}
+
+func (e *actualEvent) Button() MouseButton {
+	return e.detail.Button
+}
+
+func (e *actualEvent) Buttons() int {
+	return e.detail.Buttons
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+)
+
+type eventHandler struct {
+	widget  Widget
+	handler func(ev Event)
+}
+
+// eventBus delivers the events sent by the pages of an App
+// to the handlers registered for the target widgets.
+// The pages only send the events having handlers; see eventsCommand.
+type eventBus struct {
+	mu       sync.Mutex
+	handlers map[WidgetID]map[string][]*eventHandler
+	changed  bool
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{
+		handlers: make(map[WidgetID]map[string][]*eventHandler),
+	}
+}
+
+func (b *eventBus) subscribe(id WidgetID, event string, h *eventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.handlers[id] == nil {
+		b.handlers[id] = make(map[string][]*eventHandler)
+	}
+	if len(b.handlers[id][event]) == 0 {
+		b.changed = true
+	}
+	b.handlers[id][event] = append(b.handlers[id][event], h)
+}
+
+func (b *eventBus) unsubscribe(id WidgetID, event string, h *eventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	handlers := b.handlers[id][event]
+	for i, x := range handlers {
+		if x == h {
+			b.handlers[id][event] = append(handlers[:i:i], handlers[i+1:]...)
+			break
+		}
+	}
+	if _, ok := b.handlers[id][event]; ok && len(b.handlers[id][event]) == 0 {
+		delete(b.handlers[id], event)
+		b.changed = true
+	}
+	if _, ok := b.handlers[id]; ok && len(b.handlers[id]) == 0 {
+		delete(b.handlers, id)
+	}
+}
+
+func (b *eventBus) unsubscribeAll(id WidgetID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.handlers[id]; ok {
+		delete(b.handlers, id)
+		b.changed = true
+	}
+}
+
+// hasChanged reports whether events got their first handler or lost their
+// last one since the last call of subscriptions.
+func (b *eventBus) hasChanged() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.changed
+}
+
+// subscriptions returns the names of the events having handlers,
+// by the number of the ID of the target widget.
+func (b *eventBus) subscriptions() map[string][]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.changed = false
+	subs := make(map[string][]string, len(b.handlers))
+	for id, events := range b.handlers {
+		names := make([]string, 0, len(events))
+		for name := range events {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		subs[strconv.Itoa(int(id))] = names
+	}
+	return subs
+}
+
+func (b *eventBus) publish(rev *rawEvent) {
+	id, err := strconv.Atoi(rev.Target)
+	if err != nil {
+		return
+	}
+
+	b.mu.Lock()
+	handlers := append([]*eventHandler(nil), b.handlers[WidgetID(id)][rev.EventName]...)
+	b.mu.Unlock()
+
+	for _, h := range handlers {
+		h.handler(&actualEvent{
+			target:    h.widget,
+			eventName: rev.EventName,
+			props:     rev.Props,
+			detail:    rev.Detail,
+		})
+	}
+}
+
+// AddEventHandler registers handler to be called when the event
+// is sent by a page for w, and returns a function removing the handler.
+// The handler is registered for the current ID of w, so it has to be
+// registered again if w gets another ID, e.g. when it is attached again.
+func (app *App) AddEventHandler(w Widget, event string, handler func(ev Event)) func() {
+	h := &eventHandler{
+		widget:  w,
+		handler: handler,
+	}
+	id := w.ID()
+	app.bus.subscribe(id, event, h)
+	app.scheduleFlush()
+	return func() {
+		app.bus.unsubscribe(id, event, h)
+		app.scheduleFlush()
+	}
+}
+
+// RemoveEventHandlers removes all the event handlers registered for w.
+func (app *App) RemoveEventHandlers(w Widget) {
+	app.bus.unsubscribeAll(w.ID())
+	app.scheduleFlush()
+}
+
+// eventsCommand returns the command telling the pages which events of which
+// widgets to send, so that e.g. key presses in an input without a handler
+// don't go over the WebSocket.
+func (app *App) eventsCommand() string {
+	return command(map[string]interface{}{
+		"command": "events",
+		"events":  app.bus.subscriptions(),
+	})
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// subscriptionsSent returns the events having handlers according to the last
+// events command among msgs, or nil if there is none.
+func subscriptionsSent(t *testing.T, msgs []string) map[string][]string {
+	t.Helper()
+
+	var subs map[string][]string
+	for _, msg := range msgs {
+		var cmd struct {
+			Command string              `json:"command"`
+			Events  map[string][]string `json:"events"`
+		}
+		if err := json.Unmarshal([]byte(msg), &cmd); err != nil {
+			t.Fatal(err)
+		}
+		if cmd.Command == "events" {
+			subs = cmd.Events
+		}
+	}
+	return subs
+}
+
+func TestEventsCommand(t *testing.T) {
+	button := newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<button id="%s">OK</button>`, id)
+	})
+	app, conn := newTestApp(t, button)
+	id := fmt.Sprint(int(button.ID()))
+
+	cancelClick := app.AddEventHandler(button, "click", func(Event) {})
+	app.AddEventHandler(button, "keydown", func(Event) {})
+	cancelClick2 := app.AddEventHandler(button, "click", func(Event) {})
+	want := map[string][]string{id: {"click", "keydown"}}
+	if got := subscriptionsSent(t, receiveAll(app, conn)); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	// The page keeps sending clicks while a handler is left.
+	cancelClick()
+	if got := subscriptionsSent(t, receiveAll(app, conn)); got != nil {
+		t.Errorf("events = %v sent without any change", got)
+	}
+	cancelClick2()
+	want = map[string][]string{id: {"keydown"}}
+	if got := subscriptionsSent(t, receiveAll(app, conn)); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	app.RemoveEventHandlers(button)
+	want = map[string][]string{}
+	if got := subscriptionsSent(t, receiveAll(app, conn)); !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
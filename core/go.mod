@@ -3,7 +3,6 @@ module github.com/i2y/oden/core
 go 1.17
 
 require (
-	github.com/asaskevich/EventBus v0.0.0-20200907212545-49d423059eef
 	github.com/jchv/go-webview2 v0.0.0-20211201160117-0a1f544bbf5a
 	golang.org/x/net v0.0.0-20211209124913-491a49abca63
 )
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+// clientQueueSize is the number of messages queued for a client
+// before it is considered too slow and disconnected.
+const clientQueueSize = 256
+
+// client is a connection of a window, either a WebSocket or a Conn.
+type client struct {
+	ws        *websocket.Conn
+	msgs      chan string
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *client) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// hub broadcasts messages to every client connected to a window.
+// Broadcasting never blocks: each client has its own buffered queue,
+// and messages are simply dropped when no client is connected.
+type hub struct {
+	mu      sync.Mutex
+	clients map[*client]struct{}
+}
+
+func newHub() *hub {
+	return &hub{
+		clients: make(map[*client]struct{}),
+	}
+}
+
+func (h *hub) add(ws *websocket.Conn, queueSize int) *client {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c := &client{
+		ws:   ws,
+		msgs: make(chan string, queueSize),
+		done: make(chan struct{}),
+	}
+	h.clients[c] = struct{}{}
+	return c
+}
+
+// remove unregisters c and returns the number of remaining clients.
+func (h *hub) remove(c *client) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.clients, c)
+	c.close()
+	return len(h.clients)
+}
+
+func (h *hub) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.clients)
+}
+
+func (h *hub) broadcast(msg string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for c := range h.clients {
+		select {
+		case c.msgs <- msg:
+		default:
+			// The client can't keep up; disconnect it instead of blocking everyone else.
+			delete(h.clients, c)
+			c.close()
+		}
+	}
+}
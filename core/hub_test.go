@@ -0,0 +1,117 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// received returns the messages queued for c.
+func received(c *client) []string {
+	var msgs []string
+	for {
+		select {
+		case msg := <-c.msgs:
+			msgs = append(msgs, msg)
+		default:
+			return msgs
+		}
+	}
+}
+
+// broadcastWithin broadcasts msg, failing the test if it blocks.
+func broadcastWithin(t *testing.T, h *hub, msg string) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.broadcast(msg)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("broadcast of %q blocked", msg)
+	}
+}
+
+func TestHubBroadcastReachesEveryClient(t *testing.T) {
+	h := newHub()
+	clients := []*client{
+		h.add(nil, clientQueueSize),
+		h.add(nil, clientQueueSize),
+		h.add(nil, clientQueueSize),
+	}
+
+	broadcastWithin(t, h, "a")
+	broadcastWithin(t, h, "b")
+	for i, c := range clients {
+		if got := received(c); !reflect.DeepEqual(got, []string{"a", "b"}) {
+			t.Errorf("client %d received %q, want [a b]", i, got)
+		}
+	}
+
+	// A removed client doesn't receive the later messages.
+	if n := h.remove(clients[0]); n != 2 {
+		t.Errorf("%d clients left, want 2", n)
+	}
+	broadcastWithin(t, h, "c")
+	if got := received(clients[0]); len(got) != 0 {
+		t.Errorf("removed client received %q", got)
+	}
+	if got := received(clients[1]); !reflect.DeepEqual(got, []string{"c"}) {
+		t.Errorf("client received %q, want [c]", got)
+	}
+}
+
+func TestHubBroadcastWithoutClients(t *testing.T) {
+	h := newHub()
+	for i := 0; i < 2*clientQueueSize; i++ {
+		broadcastWithin(t, h, "lost")
+	}
+
+	// A client connecting later only receives the later messages.
+	c := h.add(nil, clientQueueSize)
+	broadcastWithin(t, h, "a")
+	if got := received(c); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("client received %q, want [a]", got)
+	}
+}
+
+func TestHubDropsSlowClient(t *testing.T) {
+	h := newHub()
+	slow := h.add(nil, 2)
+	fast := h.add(nil, clientQueueSize)
+
+	for _, msg := range []string{"a", "b", "c"} {
+		broadcastWithin(t, h, msg)
+		received(fast)
+	}
+
+	select {
+	case <-slow.done:
+	default:
+		t.Error("slow client not closed")
+	}
+	if n := h.count(); n != 1 {
+		t.Errorf("%d clients left, want the fast one", n)
+	}
+	if got := received(slow); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("slow client received %q before being dropped, want [a b]", got)
+	}
+
+	broadcastWithin(t, h, "d")
+	if got := received(fast); !reflect.DeepEqual(got, []string{"d"}) {
+		t.Errorf("fast client received %q, want [d]", got)
+	}
+	select {
+	case <-fast.done:
+		t.Error("fast client closed")
+	default:
+	}
+
+	// Removing a dropped client is harmless.
+	if n := h.remove(slow); n != 1 {
+		t.Errorf("%d clients left after removing the dropped one, want 1", n)
+	}
+}
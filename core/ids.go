@@ -0,0 +1,104 @@
+package core
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// WidgetID identifies a widget in the pages of an App.
+type WidgetID int
+
+func (id WidgetID) String() string {
+	return fmt.Sprintf("oden-%d", id)
+}
+
+// keyedIDBase is the first ID derived from a key. IDs allocated in sequence
+// stay below it, so they never collide with IDs derived from keys.
+const keyedIDBase = 1 << 30
+
+// IDAllocator allocates widget IDs. Widgets get their IDs from the allocator
+// of the App they are attached to, in the order they are attached, so the IDs
+// of a widget tree don't depend on other Apps or on the order of construction.
+type IDAllocator struct {
+	mu     sync.Mutex
+	last   WidgetID
+	keys   map[WidgetID]string
+	keyIDs map[string]WidgetID
+}
+
+func NewIDAllocator() *IDAllocator {
+	return &IDAllocator{
+		keys:   make(map[WidgetID]string),
+		keyIDs: make(map[string]WidgetID),
+	}
+}
+
+// Next returns a new ID.
+func (a *IDAllocator) Next() WidgetID {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.last++
+	return a.last
+}
+
+// Key returns the ID derived from key. The same key always gives the same ID,
+// whatever the allocator and the order of allocation, so a widget with a key
+// keeps its ID across runs. In the rare case where the hash of a key collides
+// with the one of another key, the key gets the next free ID, so its ID
+// depends on which of the keys was used first.
+func (a *IDAllocator) Key(key string) WidgetID {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if id, ok := a.keyIDs[key]; ok {
+		return id
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	n := int(h.Sum32() % keyedIDBase)
+	for {
+		id := WidgetID(keyedIDBase + n)
+		if _, ok := a.keys[id]; !ok {
+			a.keys[id] = key
+			a.keyIDs[key] = id
+			return id
+		}
+		n = (n + 1) % keyedIDBase
+	}
+}
+
+// Reset makes the allocator allocate IDs from the beginning again,
+// e.g. between tests.
+func (a *IDAllocator) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.last = 0
+	a.keys = make(map[WidgetID]string)
+	a.keyIDs = make(map[string]WidgetID)
+}
+
+// IDs returns the allocator of the IDs of the App's widgets.
+func (app *App) IDs() *IDAllocator {
+	return app.ids
+}
+
+// defaultIDs allocates the IDs of widgets rendered without being attached to an App.
+var defaultIDs = NewIDAllocator()
+
+// NewWidgetID returns a new ID for a widget not attached to any App.
+func NewWidgetID() WidgetID {
+	return defaultIDs.Next()
+}
+
+// KeyedWidgetID returns the ID derived from key for a widget not attached to any App.
+func KeyedWidgetID(key string) WidgetID {
+	return defaultIDs.Key(key)
+}
+
+// ResetWidgetIDs resets the allocator used by NewWidgetID and KeyedWidgetID.
+func ResetWidgetIDs() {
+	defaultIDs.Reset()
+}
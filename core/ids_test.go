@@ -0,0 +1,93 @@
+package core
+
+import (
+	"fmt"
+	"hash/fnv"
+	"testing"
+)
+
+func TestIDAllocatorNext(t *testing.T) {
+	a := NewIDAllocator()
+	for want := WidgetID(1); want <= 3; want++ {
+		if id := a.Next(); id != want {
+			t.Errorf("Next() = %v, want %v", id, want)
+		}
+	}
+	if s := WidgetID(3).String(); s != "oden-3" {
+		t.Errorf("String() = %q, want oden-3", s)
+	}
+}
+
+func TestIDAllocatorKey(t *testing.T) {
+	a, b := NewIDAllocator(), NewIDAllocator()
+	b.Next()
+	b.Key("other")
+
+	id := a.Key("save-button")
+	if id < keyedIDBase {
+		t.Errorf("Key() = %v, want an ID from %v", id, WidgetID(keyedIDBase))
+	}
+	if again := a.Key("save-button"); again != id {
+		t.Errorf("Key() = %v the second time, want %v", again, id)
+	}
+	if other := b.Key("save-button"); other != id {
+		t.Errorf("Key() = %v in another allocator, want %v", other, id)
+	}
+	if next := a.Next(); next != 1 {
+		t.Errorf("Next() = %v after Key, want 1", next)
+	}
+}
+
+// collidingKeys returns two keys whose hashes give the same ID.
+func collidingKeys(t *testing.T) (string, string) {
+	t.Helper()
+
+	seen := make(map[uint32]string)
+	for i := 0; i < 1<<20; i++ {
+		key := fmt.Sprintf("row-%d", i)
+		h := fnv.New32a()
+		h.Write([]byte(key))
+		n := h.Sum32() % keyedIDBase
+		if k, ok := seen[n]; ok {
+			return k, key
+		}
+		seen[n] = key
+	}
+	t.Fatal("no colliding keys found")
+	return "", ""
+}
+
+func TestIDAllocatorKeyCollision(t *testing.T) {
+	k1, k2 := collidingKeys(t)
+	a := NewIDAllocator()
+
+	id1 := a.Key(k1)
+	id2 := a.Key(k2)
+	if id1 == id2 {
+		t.Fatalf("keys %q and %q both got %v", k1, k2, id1)
+	}
+	if id2 != id1+1 {
+		t.Errorf("Key(%q) = %v, want the next ID %v", k2, id2, id1+1)
+	}
+	if a.Key(k1) != id1 || a.Key(k2) != id2 {
+		t.Error("colliding keys don't keep their IDs")
+	}
+}
+
+func TestIDAllocatorReset(t *testing.T) {
+	k1, k2 := collidingKeys(t)
+	a := NewIDAllocator()
+	a.Next()
+	a.Next()
+	id1 := a.Key(k1)
+	a.Key(k2)
+
+	a.Reset()
+	if id := a.Next(); id != 1 {
+		t.Errorf("Next() = %v after Reset, want 1", id)
+	}
+	// The keys are forgotten, so the second key gets the hashed ID now.
+	if id := a.Key(k2); id != id1 {
+		t.Errorf("Key(%q) = %v after Reset, want %v", k2, id, id1)
+	}
+}
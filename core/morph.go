@@ -0,0 +1,353 @@
+package core
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// patch is a set of changes turning the nodes rendered by a widget in a page
+// into the ones of its new view. The rendered nodes, called the run, are
+// consecutive siblings located from the element with the widget ID: Depth
+// is the depth of that element in the run and Index the index of its ancestor
+// in the run, as they are in the page before the patch. The paths of
+// the operations start with an index in the run.
+type patch struct {
+	Target string    `json:"target"`
+	Depth  int       `json:"depth"`
+	Index  int       `json:"index"`
+	Ops    []patchOp `json:"ops"`
+}
+
+// run is the location of the nodes rendered by a widget: the index of the node
+// containing the element with the widget ID, the depth of the element in that
+// node and the number of nodes.
+type run struct {
+	index, depth, length int
+}
+
+var (
+	// errNotShown is returned by Window.patch if the widget is not in the page.
+	errNotShown = errors.New("widget not shown")
+	// errUnknownRun is returned by Window.patch if the nodes rendered by
+	// the widget can't be told apart from their siblings, so that the page
+	// is to be synced instead.
+	errUnknownRun = errors.New("nodes of the widget not found")
+)
+
+type patchOp struct {
+	Op    string `json:"op"`
+	Path  []int  `json:"path"`
+	Index int    `json:"index,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+	HTML  string `json:"html,omitempty"`
+}
+
+func parseView(view string, context *html.Node) ([]*html.Node, error) {
+	return html.ParseFragment(strings.NewReader(view), &html.Node{
+		Type:     html.ElementNode,
+		Data:     context.Data,
+		DataAtom: context.DataAtom,
+	})
+}
+
+func newBody() *html.Node {
+	return &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	}
+}
+
+// resetDOM makes view the content of the copy of the page kept by the window.
+func (win *Window) resetDOM(view string) {
+	body := newBody()
+	nodes, err := parseView(view, body)
+	if err == nil {
+		for _, n := range nodes {
+			body.AppendChild(n)
+		}
+	}
+
+	win.mu.Lock()
+	win.dom = body
+	win.runs = make(map[WidgetID]run)
+	win.mu.Unlock()
+}
+
+// patch returns the patch turning the nodes of the widget with the given ID
+// in the page into view, and applies it to the copy of the page kept by the window.
+//
+// The nodes of a widget are known once it has been patched. Until then, they
+// are assumed to be located as in view, which holds unless the structure of
+// the view changes, and errUnknownRun is returned if the nodes assumed around
+// the one holding the widget contain other widgets than view. Nodes dropped
+// by such a first patch after the previous ones were rendered by an ancestor
+// can't be told from the ones of the ancestor, and are left in the page.
+func (win *Window) patch(id WidgetID, view string) (*patch, error) {
+	win.mu.Lock()
+	defer win.mu.Unlock()
+
+	anchor := findElement(win.dom, id.String())
+	if anchor == nil {
+		return nil, errNotShown
+	}
+
+	nodes, err := parseView(view, newBody())
+	if err != nil {
+		return nil, errNotShown
+	}
+	index, depth, ok := locate(nodes, id.String())
+	if !ok {
+		return nil, errNotShown
+	}
+	cur, known := win.runs[id]
+	if !known {
+		cur = run{index, depth, len(nodes)}
+	}
+
+	top := anchor
+	for i := 0; i < cur.depth; i++ {
+		if top.Parent == nil {
+			return nil, errUnknownRun
+		}
+		top = top.Parent
+	}
+	parent := top.Parent
+	if top == win.dom || parent == nil {
+		return nil, errUnknownRun
+	}
+	if parent.DataAtom != atom.Body {
+		// Parse the view again in the right context, e.g. for table rows.
+		if nodes, err = parseView(view, parent); err != nil {
+			return nil, errNotShown
+		}
+		i, d, ok := locate(nodes, id.String())
+		if !ok || i != index || d != depth {
+			return nil, errUnknownRun
+		}
+	}
+
+	first := top
+	for i := 0; i < cur.index; i++ {
+		if first = first.PrevSibling; first == nil {
+			return nil, errUnknownRun
+		}
+	}
+	var old []*html.Node
+	for n := first; n != nil && len(old) < cur.length; n = n.NextSibling {
+		old = append(old, n)
+	}
+	if len(old) < cur.length {
+		return nil, errUnknownRun
+	}
+	if !known {
+		// The nodes assumed around the one holding the widget may be
+		// siblings rendered by other widgets, e.g. if the view got longer.
+		shown := make(map[WidgetID]bool)
+		for _, n := range nodes {
+			forEachWidget(n, func(id WidgetID) {
+				shown[id] = true
+			})
+		}
+		for _, n := range old {
+			if n == top {
+				continue
+			}
+			foreign := false
+			forEachWidget(n, func(id WidgetID) {
+				foreign = foreign || !shown[id]
+			})
+			if foreign {
+				return nil, errUnknownRun
+			}
+		}
+	}
+
+	p := &patch{
+		Target: id.String(),
+		Depth:  cur.depth,
+		Index:  cur.index,
+	}
+	diffNodes(nil, old, nodes, &p.Ops)
+
+	next := run{index, depth, len(nodes)}
+	if next != cur {
+		// The nodes of the widgets rendering this one, if any, changed too.
+		for other, r := range win.runs {
+			e := findElement(win.dom, other.String())
+			for i := 0; e != nil && i < r.depth; i++ {
+				e = e.Parent
+			}
+			if e == nil || e.Parent == parent {
+				delete(win.runs, other)
+			}
+		}
+	}
+	for _, n := range old {
+		forEachWidget(n, func(id WidgetID) {
+			delete(win.widgets, id)
+			delete(win.runs, id)
+		})
+	}
+	for _, n := range nodes {
+		forEachWidget(n, func(id WidgetID) {
+			win.widgets[id] = struct{}{}
+		})
+	}
+	win.runs[id] = next
+
+	after := old[len(old)-1].NextSibling
+	for _, n := range old {
+		parent.RemoveChild(n)
+	}
+	for _, n := range nodes {
+		parent.InsertBefore(n, after)
+	}
+	return p, nil
+}
+
+// learnRun records that the nodes of the widget with the given ID in the page
+// are the ones of view, e.g. after a sync.
+func (win *Window) learnRun(id WidgetID, view string) {
+	nodes, err := parseView(view, newBody())
+	if err != nil {
+		return
+	}
+	index, depth, ok := locate(nodes, id.String())
+	if !ok {
+		return
+	}
+
+	win.mu.Lock()
+	defer win.mu.Unlock()
+
+	if findElement(win.dom, id.String()) != nil {
+		win.runs[id] = run{index, depth, len(nodes)}
+	}
+}
+
+func findElement(n *html.Node, id string) *html.Node {
+	if n.Type == html.ElementNode {
+		if v, ok := attrValue(n, "id"); ok && v == id {
+			return n
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if e := findElement(c, id); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// forEachWidget calls f with the ID of every widget rendering an element in n.
+func forEachWidget(n *html.Node, f func(id WidgetID)) {
+	if n.Type == html.ElementNode {
+		if v, ok := attrValue(n, "id"); ok && strings.HasPrefix(v, "oden-") {
+			if id, err := strconv.Atoi(strings.TrimPrefix(v, "oden-")); err == nil {
+				f(WidgetID(id))
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		forEachWidget(c, f)
+	}
+}
+
+// locate returns the index in nodes of the node containing the element
+// with the given ID, and the depth of the element in that node.
+func locate(nodes []*html.Node, id string) (int, int, bool) {
+	for i, n := range nodes {
+		e := findElement(n, id)
+		if e == nil {
+			continue
+		}
+		depth := 0
+		for ; e != n; e = e.Parent {
+			depth++
+		}
+		return i, depth, true
+	}
+	return 0, 0, false
+}
+
+func render(n *html.Node) string {
+	var b strings.Builder
+	html.Render(&b, n)
+	return b.String()
+}
+
+func childPath(path []int, i int) []int {
+	p := make([]int, len(path)+1)
+	copy(p, path)
+	p[len(path)] = i
+	return p
+}
+
+// diffNodes appends to ops the operations turning the children old of the node
+// at path into new. Operations on a child don't change the paths of its siblings,
+// and extra children are removed from the last one before new ones are inserted.
+func diffNodes(path []int, old, new []*html.Node, ops *[]patchOp) {
+	i := 0
+	for ; i < len(old) && i < len(new); i++ {
+		diffNode(childPath(path, i), old[i], new[i], ops)
+	}
+	for j := len(old) - 1; j >= len(new); j-- {
+		*ops = append(*ops, patchOp{Op: "remove", Path: childPath(path, j)})
+	}
+	for ; i < len(new); i++ {
+		*ops = append(*ops, patchOp{Op: "insert", Path: path, Index: i, HTML: render(new[i])})
+	}
+}
+
+func diffNode(path []int, old, new *html.Node, ops *[]patchOp) {
+	if old.Type == html.TextNode && new.Type == html.TextNode {
+		if old.Data != new.Data {
+			*ops = append(*ops, patchOp{Op: "text", Path: path, Value: new.Data})
+		}
+		return
+	}
+
+	oldID, _ := attrValue(old, "id")
+	newID, _ := attrValue(new, "id")
+	if old.Type != html.ElementNode || new.Type != html.ElementNode || old.Data != new.Data || oldID != newID {
+		if render(old) != render(new) {
+			*ops = append(*ops, patchOp{Op: "replace", Path: path, HTML: render(new)})
+		}
+		return
+	}
+
+	for _, a := range new.Attr {
+		if v, ok := attrValue(old, a.Key); !ok || v != a.Val {
+			*ops = append(*ops, patchOp{Op: "attr", Path: path, Name: a.Key, Value: a.Val})
+		}
+	}
+	for _, a := range old.Attr {
+		if _, ok := attrValue(new, a.Key); !ok {
+			*ops = append(*ops, patchOp{Op: "removeAttr", Path: path, Name: a.Key})
+		}
+	}
+	diffNodes(path, children(old), children(new), ops)
+}
+
+func attrValue(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+func children(n *html.Node) []*html.Node {
+	var nodes []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		nodes = append(nodes, c)
+	}
+	return nodes
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func parseNodes(t *testing.T, s string) []*html.Node {
+	t.Helper()
+
+	nodes, err := parseView(s, newBody())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return nodes
+}
+
+func TestDiffNodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []patchOp
+	}{
+		{"same", `<p class="a">x</p>`, `<p class="a">x</p>`, nil},
+		{"text", `<p>a</p>`, `<p>b</p>`, []patchOp{
+			{Op: "text", Path: []int{0, 0}, Value: "b"},
+		}},
+		{"attributes", `<p class="a" hidden>x</p>`, `<p class="b" title="t">x</p>`, []patchOp{
+			{Op: "attr", Path: []int{0}, Name: "class", Value: "b"},
+			{Op: "attr", Path: []int{0}, Name: "title", Value: "t"},
+			{Op: "removeAttr", Path: []int{0}, Name: "hidden"},
+		}},
+		{"insert", `<ul><li>a</li></ul>`, `<ul><li>a</li><li>b</li><li>c</li></ul>`, []patchOp{
+			{Op: "insert", Path: []int{0}, Index: 1, HTML: `<li>b</li>`},
+			{Op: "insert", Path: []int{0}, Index: 2, HTML: `<li>c</li>`},
+		}},
+		{"remove", `<ul><li>a</li><li>b</li><li>c</li></ul>`, `<ul><li>a</li></ul>`, []patchOp{
+			{Op: "remove", Path: []int{0, 2}},
+			{Op: "remove", Path: []int{0, 1}},
+		}},
+		{"reorder", `<ul><li id="oden-1">a</li><li id="oden-2">b</li></ul>`, `<ul><li id="oden-2">b</li><li id="oden-1">a</li></ul>`, []patchOp{
+			{Op: "replace", Path: []int{0, 0}, HTML: `<li id="oden-2">b</li>`},
+			{Op: "replace", Path: []int{0, 1}, HTML: `<li id="oden-1">a</li>`},
+		}},
+		{"tag", `<b>a</b>`, `<i>a</i>`, []patchOp{
+			{Op: "replace", Path: []int{0}, HTML: `<i>a</i>`},
+		}},
+		{"longer run", `<b id="oden-1">a</b>`, `<b id="oden-1">a</b><i>extra</i>`, []patchOp{
+			{Op: "insert", Index: 1, HTML: `<i>extra</i>`},
+		}},
+		{"shorter run", `<b id="oden-1">a</b><i>extra</i>text`, `<b id="oden-1">b</b>`, []patchOp{
+			{Op: "text", Path: []int{0, 0}, Value: "b"},
+			{Op: "remove", Path: []int{2}},
+			{Op: "remove", Path: []int{1}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []patchOp
+			diffNodes(nil, parseNodes(t, tt.old), parseNodes(t, tt.new), &ops)
+			if !reflect.DeepEqual(ops, tt.want) {
+				t.Errorf("ops = %+v, want %+v", ops, tt.want)
+			}
+		})
+	}
+}
+
+// assertDOM fails the test if the copy of the page kept by win differs from its view.
+func assertDOM(t *testing.T, win *Window) {
+	t.Helper()
+
+	var dom strings.Builder
+	for _, n := range children(win.dom) {
+		dom.WriteString(render(n))
+	}
+	var view strings.Builder
+	for _, n := range parseNodes(t, win.View()) {
+		view.WriteString(render(n))
+	}
+	if dom.String() != view.String() {
+		t.Errorf("DOM = %s, want %s", dom.String(), view.String())
+	}
+}
+
+func TestPatchRunOfChangingLength(t *testing.T) {
+	extra := 0
+	child := newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<b id="%s">a</b>%s`, id, strings.Repeat("<i>extra</i>", extra))
+	})
+	neighbour := newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<span id="%s">neighbour</span>`, id)
+	})
+	parent := newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<div id="%s">%s%s</div>`, id, child.View(), neighbour.View())
+	}, child, neighbour)
+	app, conn := newTestApp(t, parent)
+	win := app.MainWindow()
+
+	update := func(n int) []string {
+		app.call(func() {
+			extra = n
+			app.PostUpdate(child)
+		})
+		return receiveAll(app, conn)
+	}
+
+	// Until the child is patched, its nodes are only known to be its element,
+	// so that the page is synced rather than overwriting the neighbour.
+	msgs := update(1)
+	if len(msgs) != 1 || !strings.Contains(msgs[0], `"sync"`) {
+		t.Errorf("messages = %q, want a sync", msgs)
+	}
+	assertDOM(t, win)
+
+	for _, n := range []int{3, 0, 2} {
+		msgs := update(n)
+		if len(msgs) != 1 || !strings.Contains(msgs[0], `"patch"`) {
+			t.Errorf("messages = %q after a view of %d extra nodes, want a patch", msgs, n)
+		}
+		assertDOM(t, win)
+	}
+	if !win.shows(neighbour.ID()) {
+		t.Error("neighbour not shown anymore")
+	}
+
+	// A re-rendering of the parent makes the nodes of the child unknown again.
+	app.call(func() {
+		extra = 1
+		app.PostUpdate(parent)
+	})
+	receiveAll(app, conn)
+	if _, ok := win.runs[child.ID()]; ok {
+		t.Error("nodes of the child still known after the parent was rendered")
+	}
+	assertDOM(t, win)
+	msgs = update(2)
+	if len(msgs) != 1 || !strings.Contains(msgs[0], `"sync"`) {
+		t.Errorf("messages = %q, want a sync", msgs)
+	}
+	assertDOM(t, win)
+}
@@ -0,0 +1,364 @@
+/*
+Package odentest drives Oden apps in plain `go test`, without a browser.
+
+A Driver builds an App around a widget tree and connects to its main window
+in memory. Tests dispatch synthetic events to widgets, found by ID or by a
+simple query on the rendered HTML, and inspect the updates the App sent.
+
+	count := widget.State(0)
+	d := odentest.New(t, widget.Column(
+		widget.Text(count),
+		widget.Button("+").OnClick(func(core.Event) {
+			count.Update(func(n int) int { return n + 1 })
+		}),
+	))
+	d.Click(d.Find(`sl-button`))
+	if count.Get() != 1 {
+		t.Errorf("count = %d, want 1", count.Get())
+	}
+
+Snapshot compares the HTML of a widget with a golden file in testdata,
+which is rewritten when the test runs with ODEN_UPDATE_GOLDEN=1.
+*/
+package odentest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	core "github.com/i2y/oden/core"
+)
+
+// Update is an update of the page sent by the App.
+// Action is "sync" for the whole page, whose Target is "oden-body",
+// or "patch" for a widget. HTML is the HTML of the target after the update.
+type Update struct {
+	Action string
+	Target string
+	HTML   string
+}
+
+// Driver drives an App without a browser.
+type Driver struct {
+	t       testing.TB
+	app     *core.App
+	conn    *core.Conn
+	page    *page
+	updates []Update
+}
+
+// New returns a Driver of an App showing view.
+// The App quits, closing its listener, when the test finishes.
+func New(t testing.TB, view core.Widget, options ...func(*core.AppOptions)) *Driver {
+	t.Helper()
+
+	app := core.NewApp(t.Name(), view, options...)
+	d := &Driver{
+		t:    t,
+		app:  app,
+		conn: app.MainWindow().Connect(),
+		page: newPage(),
+	}
+	t.Cleanup(func() {
+		d.conn.Close()
+		app.MainWindow().Close()
+	})
+	d.collect()
+	d.updates = nil
+	return d
+}
+
+// App returns the driven App.
+func (d *Driver) App() *core.App {
+	return d.app
+}
+
+// HTML returns the current HTML of the main window,
+// i.e. the view followed by the overlays such as dialogs.
+func (d *Driver) HTML() string {
+	var view string
+	d.Do(func() {
+		view = d.app.MainWindow().View()
+	})
+	return view
+}
+
+// Do runs f on the UI goroutine of the App and waits for it to return.
+// Models shown by the view must be changed in f; see core.App.Dispatch.
+func (d *Driver) Do(f func()) {
+	done := make(chan struct{})
+	d.app.Dispatch(func() {
+		defer close(done)
+		f()
+	})
+	<-done
+	d.collect()
+}
+
+// Page returns the HTML of the page as updated by the messages sent by the App,
+// which should be the same as HTML once the pending updates are collected.
+func (d *Driver) Page() string {
+	d.collect()
+	return d.page.html()
+}
+
+// Dispatch sends an event to the widget with the given ID
+// as if the browser sent it, and collects the resulting updates.
+// The value and checked properties in props are set to the element of
+// the widget in the page, as if the user had entered them.
+func (d *Driver) Dispatch(id core.WidgetID, event string, props map[string]interface{}) {
+	d.t.Helper()
+
+	d.DispatchDetail(id, event, props, core.EventDetail{})
+}
+
+// DispatchDetail is like Dispatch but also sends the details of the event,
+// e.g. the key of a keyboard event.
+func (d *Driver) DispatchDetail(id core.WidgetID, event string, props map[string]interface{}, detail core.EventDetail) {
+	d.t.Helper()
+
+	d.page.enter(id, props)
+	if err := d.conn.DispatchDetail(id, event, props, detail); err != nil {
+		d.t.Fatalf("failed to dispatch %s to %s: %v", event, id, err)
+	}
+	d.collect()
+}
+
+// Click dispatches a click event to the widget with the given ID.
+func (d *Driver) Click(id core.WidgetID) {
+	d.t.Helper()
+	d.Dispatch(id, "click", nil)
+}
+
+// Change dispatches an sl-change event with the given target properties
+// to the widget with the given ID.
+func (d *Driver) Change(id core.WidgetID, props map[string]interface{}) {
+	d.t.Helper()
+	d.Dispatch(id, "sl-change", props)
+}
+
+// Input dispatches an sl-input event, as sent while typing in an input,
+// to the widget with the given ID.
+func (d *Driver) Input(id core.WidgetID, value string) {
+	d.t.Helper()
+	d.Dispatch(id, "sl-input", map[string]interface{}{"value": value})
+}
+
+// KeyDown dispatches a keydown event of the given key, e.g. "a" or "Enter",
+// with the given modifier keys held down to the widget with the given ID.
+func (d *Driver) KeyDown(id core.WidgetID, key string, modifiers core.Modifiers) {
+	d.t.Helper()
+	d.DispatchDetail(id, "keydown", nil, core.EventDetail{
+		Key:       key,
+		Modifiers: modifiers,
+	})
+}
+
+// Shortcut presses the keys of accelerator, e.g. "Ctrl+S",
+// to run the handlers registered by App.AddShortcut.
+func (d *Driver) Shortcut(accelerator string) {
+	d.t.Helper()
+
+	if err := d.conn.Shortcut(accelerator); err != nil {
+		d.t.Fatalf("failed to press %s: %v", accelerator, err)
+	}
+	d.collect()
+}
+
+// Focus dispatches a focus event to the widget with the given ID.
+func (d *Driver) Focus(id core.WidgetID) {
+	d.t.Helper()
+	d.Dispatch(id, "focus", nil)
+}
+
+// Blur dispatches a blur event to the widget with the given ID.
+func (d *Driver) Blur(id core.WidgetID) {
+	d.t.Helper()
+	d.Dispatch(id, "blur", nil)
+}
+
+// Updates returns the updates sent by the App since the Driver was created
+// or the last call of Updates.
+func (d *Driver) Updates() []Update {
+	d.collect()
+	updates := d.updates
+	d.updates = nil
+	return updates
+}
+
+// collect sends the pending updates of the App and records them.
+func (d *Driver) collect() {
+	d.app.Flush()
+	for {
+		msg, ok := d.conn.Receive()
+		if !ok {
+			return
+		}
+		updates, err := d.page.apply(msg)
+		if err != nil {
+			d.t.Errorf("failed to apply %s: %v", msg, err)
+		}
+		d.updates = append(d.updates, updates...)
+	}
+}
+
+// Find returns the ID of the widget rendering the first element matching
+// the query. It fails the test if no element matches.
+// See FindAll for the syntax of queries.
+func (d *Driver) Find(query string) core.WidgetID {
+	d.t.Helper()
+
+	ids := d.FindAll(query)
+	if len(ids) == 0 {
+		d.t.Fatalf("no widget matches %q in %s", query, d.HTML())
+	}
+	return ids[0]
+}
+
+// FindAll returns the IDs of the widgets rendering the elements matching the query,
+// in document order. The widget rendering an element is the one whose ID is the
+// id attribute of the element or of its nearest ancestor having an Oden widget ID.
+//
+// A query is a tag name and/or attribute filters, optionally followed by the text
+// the element contains:
+//
+//	sl-button
+//	sl-input[type=password]
+//	[placeholder="Your name"]
+//	sl-button:contains(OK)
+func (d *Driver) FindAll(query string) []core.WidgetID {
+	d.t.Helper()
+
+	q, err := parseQuery(query)
+	if err != nil {
+		d.t.Fatalf("invalid query %q: %v", query, err)
+	}
+
+	nodes, err := html.ParseFragment(strings.NewReader(d.HTML()), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		d.t.Fatalf("failed to parse the view: %v", err)
+	}
+
+	var ids []core.WidgetID
+	seen := make(map[core.WidgetID]bool)
+	var walk func(n *html.Node, owner core.WidgetID)
+	walk = func(n *html.Node, owner core.WidgetID) {
+		if n.Type == html.ElementNode {
+			if id, ok := widgetID(n); ok {
+				owner = id
+			}
+			if owner != 0 && q.match(n) && !seen[owner] {
+				seen[owner] = true
+				ids = append(ids, owner)
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, owner)
+		}
+	}
+	for _, n := range nodes {
+		walk(n, 0)
+	}
+	return ids
+}
+
+func widgetID(n *html.Node) (core.WidgetID, bool) {
+	for _, a := range n.Attr {
+		if a.Key != "id" || !strings.HasPrefix(a.Val, "oden-") {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(a.Val, "oden-"))
+		if err != nil {
+			return 0, false
+		}
+		return core.WidgetID(id), true
+	}
+	return 0, false
+}
+
+type attrFilter struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+type query struct {
+	tag   string
+	attrs []attrFilter
+	text  string
+}
+
+var queryPattern = regexp.MustCompile(`^([a-zA-Z0-9-]*)((?:\[[^\]]+\])*)(?::contains\((.*)\))?$`)
+var attrPattern = regexp.MustCompile(`\[([^=\]]+)(?:=("[^"]*"|'[^']*'|[^\]]*))?\]`)
+
+func parseQuery(s string) (*query, error) {
+	m := queryPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil || (m[1] == "" && m[2] == "" && m[3] == "") {
+		return nil, fmt.Errorf("unsupported syntax")
+	}
+
+	q := &query{
+		tag:  strings.ToLower(m[1]),
+		text: m[3],
+	}
+	for _, a := range attrPattern.FindAllStringSubmatch(m[2], -1) {
+		f := attrFilter{
+			key: strings.ToLower(strings.TrimSpace(a[1])),
+		}
+		if strings.Contains(a[0], "=") {
+			f.hasValue = true
+			f.value = strings.Trim(a[2], `"'`)
+		}
+		q.attrs = append(q.attrs, f)
+	}
+	return q, nil
+}
+
+func (q *query) match(n *html.Node) bool {
+	if q.tag != "" && n.Data != q.tag {
+		return false
+	}
+	for _, f := range q.attrs {
+		if !hasAttr(n, f) {
+			return false
+		}
+	}
+	if q.text != "" && !strings.Contains(textContent(n), q.text) {
+		return false
+	}
+	return true
+}
+
+func hasAttr(n *html.Node, f attrFilter) bool {
+	for _, a := range n.Attr {
+		if a.Key == f.key && (!f.hasValue || a.Val == f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
@@ -0,0 +1,405 @@
+package odentest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	core "github.com/i2y/oden/core"
+)
+
+// testWidget is a widget whose view is made by a function of its ID.
+// Its handlers are registered when it is attached.
+type testWidget struct {
+	id       core.WidgetID
+	app      *core.App
+	view     func(id core.WidgetID) string
+	children []*testWidget
+	handlers map[string]func(ev core.Event)
+}
+
+func newTestWidget(view func(id core.WidgetID) string, children ...*testWidget) *testWidget {
+	return &testWidget{
+		view:     view,
+		children: children,
+		handlers: make(map[string]func(ev core.Event)),
+	}
+}
+
+func (w *testWidget) ID() core.WidgetID {
+	return w.id
+}
+
+func (w *testWidget) View() string {
+	return w.view(w.id)
+}
+
+func (w *testWidget) Attach(app *core.App) {
+	w.app = app
+	w.id = app.IDs().Next()
+	for event, h := range w.handlers {
+		app.AddEventHandler(w, event, h)
+	}
+	for _, c := range w.children {
+		c.Attach(app)
+	}
+}
+
+// form is a widget tree with a counter button, a label and a text input.
+type form struct {
+	root, button, label, input *testWidget
+	clicks                     int
+	text                       string
+	items                      []string
+}
+
+func newForm() *form {
+	f := &form{}
+	f.button = newTestWidget(func(id core.WidgetID) string {
+		return fmt.Sprintf(`<sl-button id="%s" type="primary"><b>Add</b> %d</sl-button>`, id, f.clicks)
+	})
+	f.label = newTestWidget(func(id core.WidgetID) string {
+		var items strings.Builder
+		for _, item := range f.items {
+			fmt.Fprintf(&items, `<li>%s</li>`, item)
+		}
+		return fmt.Sprintf(`<ul id="%s" class="items">%s</ul>`, id, items.String())
+	})
+	f.input = newTestWidget(func(id core.WidgetID) string {
+		return fmt.Sprintf(`<div><sl-input id="%s" placeholder="Your name" value="%s"></sl-input></div>`, id, f.text)
+	})
+	f.root = newTestWidget(func(id core.WidgetID) string {
+		return fmt.Sprintf(`<div id="%s">%s%s%s</div>`, id, f.button.View(), f.label.View(), f.input.View())
+	}, f.button, f.label, f.input)
+
+	f.button.handlers["click"] = func(ev core.Event) {
+		f.clicks++
+		f.items = append(f.items, fmt.Sprintf("item %d", f.clicks))
+		f.button.app.PostUpdate(f.button)
+		f.button.app.PostUpdate(f.label)
+	}
+	f.input.handlers["sl-input"] = func(ev core.Event) {
+		f.text, _ = ev.StringProp("value")
+		f.input.app.PostShown(f.input)
+	}
+	return f
+}
+
+// assertInSync fails the test if the page updated by the messages of the App
+// differs from the view.
+func assertInSync(t *testing.T, d *Driver) {
+	t.Helper()
+
+	page, err := Normalize(d.Page())
+	if err != nil {
+		t.Fatal(err)
+	}
+	view, err := Normalize(d.HTML())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page != view {
+		t.Errorf("page out of sync with the view\n--- page\n%s--- view\n%s", page, view)
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	f := newForm()
+	d := New(t, f.root)
+
+	tests := []struct {
+		query string
+		want  []core.WidgetID
+	}{
+		{`sl-button`, []core.WidgetID{f.button.ID()}},
+		{`div:contains(Add)`, []core.WidgetID{f.root.ID()}},
+		{`[type=primary]`, []core.WidgetID{f.button.ID()}},
+		{`sl-button[type="primary"]`, []core.WidgetID{f.button.ID()}},
+		{`sl-button[type=danger]`, nil},
+		{`[placeholder="Your name"]`, []core.WidgetID{f.input.ID()}},
+		{`[placeholder='Your name'][value]`, []core.WidgetID{f.input.ID()}},
+		{`[class=items]`, []core.WidgetID{f.label.ID()}},
+		// An element without a widget ID belongs to its nearest widget.
+		{`b`, []core.WidgetID{f.button.ID()}},
+		{`b:contains(Add)`, []core.WidgetID{f.button.ID()}},
+		{`sl-button:contains(Add 0)`, []core.WidgetID{f.button.ID()}},
+		{`:contains(Add)`, []core.WidgetID{f.root.ID(), f.button.ID()}},
+		{`sl-button:contains(Remove)`, nil},
+	}
+	for _, tt := range tests {
+		if got := d.FindAll(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FindAll(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	if got := d.Find(`sl-input`); got != f.input.ID() {
+		t.Errorf("Find(sl-input) = %v, want %v", got, f.input.ID())
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  *query
+	}{
+		{`sl-button`, &query{tag: "sl-button"}},
+		{`SL-Button`, &query{tag: "sl-button"}},
+		{`[disabled]`, &query{attrs: []attrFilter{{key: "disabled"}}}},
+		{`input[type=text][Name="a b"]`, &query{tag: "input", attrs: []attrFilter{
+			{key: "type", value: "text", hasValue: true},
+			{key: "name", value: "a b", hasValue: true},
+		}}},
+		{`:contains(OK (1))`, &query{text: "OK (1)"}},
+	}
+	for _, tt := range tests {
+		got, err := parseQuery(tt.query)
+		if err != nil {
+			t.Errorf("parseQuery(%q) failed: %v", tt.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseQuery(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+
+	for _, s := range []string{``, `div span`, `div > span`, `ul.items`, `[a`, `div:hover`} {
+		if _, err := parseQuery(s); err == nil {
+			t.Errorf("parseQuery(%q) succeeded, want an error", s)
+		}
+	}
+}
+
+func TestClick(t *testing.T) {
+	f := newForm()
+	d := New(t, f.root)
+
+	d.Click(d.Find(`sl-button`))
+	d.Click(f.button.ID())
+	if f.clicks != 2 {
+		t.Errorf("clicks = %d, want 2", f.clicks)
+	}
+	if got := d.FindAll(`li`); len(got) != 1 || got[0] != f.label.ID() {
+		t.Errorf("FindAll(li) = %v, want the list", got)
+	}
+	if !strings.Contains(d.HTML(), "<li>item 2</li>") {
+		t.Errorf("view not updated: %s", d.HTML())
+	}
+	assertInSync(t, d)
+}
+
+func TestDispatch(t *testing.T) {
+	f := newForm()
+	var got []interface{}
+	f.input.handlers["sl-change"] = func(ev core.Event) {
+		got = append(got, ev.EventName(), ev.Target(), ev.Props()["value"], ev.Props()["count"])
+	}
+	var detail core.EventDetail
+	f.input.handlers["keydown"] = func(ev core.Event) {
+		detail = core.EventDetail{Key: ev.Key(), Modifiers: ev.Modifiers()}
+	}
+	d := New(t, f.root)
+
+	d.Dispatch(f.input.ID(), "sl-change", map[string]interface{}{"value": "Ann", "count": 3})
+	// Props go through JSON as the ones of a page do.
+	want := []interface{}{"sl-change", f.input, "Ann", float64(3)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("event = %v, want %v", got, want)
+	}
+
+	d.KeyDown(f.input.ID(), "s", core.CtrlKey)
+	if detail.Key != "s" || detail.Modifiers != core.CtrlKey {
+		t.Errorf("detail = %+v, want Ctrl+s", detail)
+	}
+
+	// Events of widgets without handlers are dropped.
+	d.Dispatch(f.label.ID(), "click", nil)
+	if f.clicks != 0 {
+		t.Errorf("clicks = %d, want 0", f.clicks)
+	}
+}
+
+func TestInputEntersValue(t *testing.T) {
+	f := newForm()
+	d := New(t, f.root)
+
+	d.Input(f.input.ID(), "Bob")
+	if f.text != "Bob" {
+		t.Errorf("text = %q, want Bob", f.text)
+	}
+	// The page shows the entered value without any update.
+	if updates := d.Updates(); len(updates) != 0 {
+		t.Errorf("updates = %+v, want none", updates)
+	}
+	assertInSync(t, d)
+}
+
+func TestUpdates(t *testing.T) {
+	f := newForm()
+	d := New(t, f.root)
+	if updates := d.Updates(); len(updates) != 0 {
+		t.Errorf("updates after New = %+v, want none", updates)
+	}
+
+	d.Click(f.button.ID())
+	updates := d.Updates()
+	if len(updates) != 2 {
+		t.Fatalf("updates = %+v, want 2", updates)
+	}
+	for i, id := range []core.WidgetID{f.button.ID(), f.label.ID()} {
+		u := updates[i]
+		if u.Action != "patch" || u.Target != id.String() {
+			t.Errorf("updates[%d] = %+v, want a patch of %s", i, u, id)
+		}
+	}
+	if !strings.Contains(updates[0].HTML, "</b> 1</sl-button>") || !strings.Contains(updates[1].HTML, "<li>item 1</li>") {
+		t.Errorf("updates = %+v, want the new views", updates)
+	}
+	if updates := d.Updates(); len(updates) != 0 {
+		t.Errorf("updates returned twice: %+v", updates)
+	}
+
+	d.Do(func() {
+		f.clicks = 10
+		d.App().PostUpdate(f.root)
+	})
+	updates = d.Updates()
+	if len(updates) != 1 || updates[0].Target != f.root.ID().String() {
+		t.Errorf("updates = %+v, want a patch of the root only", updates)
+	}
+	assertInSync(t, d)
+}
+
+func TestPageAfterPatches(t *testing.T) {
+	f := newForm()
+	d := New(t, f.root)
+
+	for i := 0; i < 3; i++ {
+		d.Click(f.button.ID())
+		assertInSync(t, d)
+	}
+	d.Do(func() {
+		f.items = f.items[1:2]
+		f.text = `"quoted" & <escaped>`
+		d.App().PostUpdate(f.label)
+		d.App().PostUpdate(f.input)
+	})
+	assertInSync(t, d)
+	d.Do(func() {
+		f.items = nil
+		d.App().PostUpdate(f.root)
+	})
+	assertInSync(t, d)
+}
+
+func TestShortcut(t *testing.T) {
+	f := newForm()
+	d := New(t, f.root)
+	var saved int
+	d.App().AddShortcut("Ctrl+S", func(core.Event) {
+		saved++
+	})
+
+	d.Shortcut("Ctrl+S")
+	d.Shortcut("Ctrl+Shift+S")
+	if saved != 1 {
+		t.Errorf("saved = %d, want 1", saved)
+	}
+}
+
+func TestUpdatesInSameFrame(t *testing.T) {
+	f := newForm()
+	d := New(t, f.root)
+
+	// A widget updated several times is patched once.
+	d.Do(func() {
+		for i := 0; i < 3; i++ {
+			f.clicks++
+			d.App().PostUpdate(f.button)
+		}
+	})
+	updates := d.Updates()
+	if len(updates) != 1 || updates[0].Target != f.button.ID().String() {
+		t.Errorf("updates = %+v, want one patch of the button", updates)
+	}
+	assertInSync(t, d)
+
+	// A child updated with its parent is patched by the patch of the parent,
+	// whichever is posted first.
+	d.Do(func() {
+		f.items = []string{"a"}
+		d.App().PostUpdate(f.label)
+		f.text = "b"
+		d.App().PostUpdate(f.root)
+		f.clicks++
+		d.App().PostUpdate(f.button)
+	})
+	updates = d.Updates()
+	if len(updates) != 1 || updates[0].Target != f.root.ID().String() {
+		t.Errorf("updates = %+v, want one patch of the root", updates)
+	}
+	assertInSync(t, d)
+}
+
+func TestUpdatesOfChildAttachedBeforeParent(t *testing.T) {
+	text := "a"
+	child := newTestWidget(func(id core.WidgetID) string {
+		return fmt.Sprintf(`<b id="%s">%s</b>`, id, text)
+	})
+	other := newTestWidget(func(id core.WidgetID) string {
+		return fmt.Sprintf(`<i id="%s">%s</i>`, id, text)
+	})
+	// The parent is attached after the child, so it has a greater ID
+	// and is rendered after it.
+	parent := newTestWidget(func(id core.WidgetID) string {
+		return fmt.Sprintf(`<p id="%s">%s %s</p>`, id, text, child.View())
+	})
+	root := newTestWidget(func(id core.WidgetID) string {
+		return fmt.Sprintf(`<div id="%s">%s%s</div>`, id, parent.View(), other.View())
+	}, child, other, parent)
+	d := New(t, root)
+	if child.ID() > parent.ID() {
+		t.Fatalf("child %s attached after its parent %s", child.ID(), parent.ID())
+	}
+
+	d.Do(func() {
+		text = "b"
+		d.App().PostUpdate(child)
+		d.App().PostUpdate(other)
+		d.App().PostUpdate(parent)
+	})
+	var targets []string
+	for _, u := range d.Updates() {
+		targets = append(targets, u.Target)
+	}
+	want := []string{other.ID().String(), parent.ID().String()}
+	if !reflect.DeepEqual(targets, want) {
+		t.Errorf("patched %v, want %v", targets, want)
+	}
+	assertInSync(t, d)
+}
+
+func TestPageAfterRunChanges(t *testing.T) {
+	extra := 0
+	child := newTestWidget(func(id core.WidgetID) string {
+		return fmt.Sprintf(`<b id="%s">a</b>%s`, id, strings.Repeat("<i>extra</i>", extra))
+	})
+	neighbour := newTestWidget(func(id core.WidgetID) string {
+		return fmt.Sprintf(`<span id="%s">neighbour</span>`, id)
+	})
+	root := newTestWidget(func(id core.WidgetID) string {
+		return fmt.Sprintf(`<div id="%s">%s%s</div>`, id, child.View(), neighbour.View())
+	}, child, neighbour)
+	d := New(t, root)
+
+	for _, n := range []int{1, 3, 0, 2} {
+		d.Do(func() {
+			extra = n
+			d.App().PostUpdate(child)
+		})
+		assertInSync(t, d)
+	}
+	if got := d.FindAll(`span:contains(neighbour)`); len(got) != 1 {
+		t.Errorf("neighbour = %v, want it kept", got)
+	}
+}
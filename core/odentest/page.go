@@ -0,0 +1,283 @@
+package odentest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	core "github.com/i2y/oden/core"
+)
+
+// page applies the commands sent by an App to a copy of the body of a page,
+// the same way as the script of the page does in a browser.
+type page struct {
+	body *html.Node
+}
+
+type command struct {
+	Command string   `json:"command"`
+	HTML    string   `json:"html"`
+	Patches []*patch `json:"patches"`
+}
+
+type patch struct {
+	Target string    `json:"target"`
+	Depth  int       `json:"depth"`
+	Index  int       `json:"index"`
+	Ops    []patchOp `json:"ops"`
+}
+
+type patchOp struct {
+	Op    string `json:"op"`
+	Path  []int  `json:"path"`
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	HTML  string `json:"html"`
+}
+
+func newPage() *page {
+	return &page{body: bodyNode()}
+}
+
+func bodyNode() *html.Node {
+	return &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	}
+}
+
+// apply applies the command msg and returns the updates it made.
+// Messages other than commands are ignored.
+func (p *page) apply(msg string) ([]Update, error) {
+	var cmd command
+	if err := json.Unmarshal([]byte(msg), &cmd); err != nil {
+		return nil, nil
+	}
+
+	switch cmd.Command {
+	case "sync":
+		nodes, err := parse(cmd.HTML, p.body)
+		if err != nil {
+			return nil, err
+		}
+		p.body = bodyNode()
+		for _, n := range nodes {
+			p.body.AppendChild(n)
+		}
+		return []Update{{Action: "sync", Target: "oden-body", HTML: cmd.HTML}}, nil
+	case "patch":
+		var updates []Update
+		for _, pt := range cmd.Patches {
+			if err := p.patch(pt); err != nil {
+				return updates, fmt.Errorf("failed to patch %s: %w", pt.Target, err)
+			}
+			u := Update{Action: "patch", Target: pt.Target}
+			if e := findElement(p.body, pt.Target); e != nil {
+				u.HTML = render(e)
+			}
+			updates = append(updates, u)
+		}
+		return updates, nil
+	}
+	return nil, nil
+}
+
+func (p *page) patch(pt *patch) error {
+	top := findElement(p.body, pt.Target)
+	if top == nil {
+		return fmt.Errorf("no element")
+	}
+	for i := 0; i < pt.Depth; i++ {
+		if top.Parent == nil {
+			return fmt.Errorf("no ancestor")
+		}
+		top = top.Parent
+	}
+	parent := top.Parent
+	if parent == nil {
+		return fmt.Errorf("no parent")
+	}
+	first := top
+	for i := 0; i < pt.Index; i++ {
+		if first.PrevSibling == nil {
+			return fmt.Errorf("no sibling")
+		}
+		first = first.PrevSibling
+	}
+	start := indexOf(first)
+
+	nodeAt := func(path []int) (*html.Node, error) {
+		if len(path) == 0 {
+			return parent, nil
+		}
+		n := child(parent, start+path[0])
+		for _, i := range path[1:] {
+			if n == nil {
+				break
+			}
+			n = child(n, i)
+		}
+		if n == nil {
+			return nil, fmt.Errorf("no node at %v", path)
+		}
+		return n, nil
+	}
+
+	for _, op := range pt.Ops {
+		n, err := nodeAt(op.Path)
+		if err != nil {
+			return err
+		}
+		switch op.Op {
+		case "attr":
+			setAttr(n, op.Name, op.Value)
+		case "removeAttr":
+			removeAttr(n, op.Name)
+		case "text":
+			n.Data = op.Value
+		case "replace":
+			nodes, err := parse(op.HTML, n.Parent)
+			if err != nil {
+				return err
+			}
+			for _, c := range nodes {
+				n.Parent.InsertBefore(c, n)
+			}
+			n.Parent.RemoveChild(n)
+		case "remove":
+			n.Parent.RemoveChild(n)
+		case "insert":
+			offset := 0
+			if len(op.Path) == 0 {
+				offset = start
+			}
+			nodes, err := parse(op.HTML, n)
+			if err != nil {
+				return err
+			}
+			next := child(n, offset+op.Index)
+			for _, c := range nodes {
+				n.InsertBefore(c, next)
+			}
+		default:
+			return fmt.Errorf("unknown operation %q", op.Op)
+		}
+	}
+	return nil
+}
+
+// enter sets the value and checked properties in props
+// to the element with the given ID, as if the user had entered them.
+// A number is set in its shortest decimal form. A list of values, such as
+// the value of a multiple sl-select, is set to the data-values attribute
+// rendering it.
+func (p *page) enter(id core.WidgetID, props map[string]interface{}) {
+	e := findElement(p.body, id.String())
+	if e == nil {
+		return
+	}
+	switch v := props["value"].(type) {
+	case string:
+		setAttr(e, "value", v)
+	case float64:
+		setAttr(e, "value", strconv.FormatFloat(v, 'f', -1, 64))
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, x := range v {
+			if s, ok := x.(string); ok {
+				values = append(values, s)
+			}
+		}
+		setAttr(e, "data-values", strings.Join(values, " "))
+	}
+	if v, ok := props["checked"].(bool); ok {
+		if v {
+			setAttr(e, "checked", "")
+		} else {
+			removeAttr(e, "checked")
+		}
+	}
+}
+
+func parse(s string, context *html.Node) ([]*html.Node, error) {
+	return html.ParseFragment(strings.NewReader(s), &html.Node{
+		Type:     html.ElementNode,
+		Data:     context.Data,
+		DataAtom: context.DataAtom,
+	})
+}
+
+func child(n *html.Node, i int) *html.Node {
+	c := n.FirstChild
+	for ; c != nil && i > 0; i-- {
+		c = c.NextSibling
+	}
+	if i < 0 {
+		return nil
+	}
+	return c
+}
+
+func indexOf(n *html.Node) int {
+	i := 0
+	for c := n.PrevSibling; c != nil; c = c.PrevSibling {
+		i++
+	}
+	return i
+}
+
+func setAttr(n *html.Node, key, val string) {
+	for i, a := range n.Attr {
+		if a.Key == key {
+			n.Attr[i].Val = val
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: val})
+}
+
+func removeAttr(n *html.Node, key string) {
+	for i, a := range n.Attr {
+		if a.Key == key {
+			n.Attr = append(n.Attr[:i:i], n.Attr[i+1:]...)
+			return
+		}
+	}
+}
+
+func findElement(n *html.Node, id string) *html.Node {
+	if n.Type == html.ElementNode {
+		for _, a := range n.Attr {
+			if a.Key == "id" && a.Val == id {
+				return n
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if e := findElement(c, id); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+func render(n *html.Node) string {
+	var b strings.Builder
+	html.Render(&b, n)
+	return b.String()
+}
+
+// html returns the content of the body.
+func (p *page) html() string {
+	var b strings.Builder
+	for c := p.body.FirstChild; c != nil; c = c.NextSibling {
+		html.Render(&b, c)
+	}
+	return b.String()
+}
@@ -0,0 +1,147 @@
+package odentest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	core "github.com/i2y/oden/core"
+)
+
+// UpdateEnv is the environment variable making Snapshot write the golden
+// files instead of comparing views with them, when it is set to 1.
+// An environment variable is used rather than a flag so that test binaries
+// are free to define their own flags.
+const UpdateEnv = "ODEN_UPDATE_GOLDEN"
+
+func updateGolden() bool {
+	return os.Getenv(UpdateEnv) == "1"
+}
+
+// Snapshot compares the normalized HTML of view with the golden file
+// testdata/<test name>.golden and fails the test if they differ.
+// Run the test with ODEN_UPDATE_GOLDEN=1 to write the golden file instead.
+func Snapshot(t testing.TB, view core.Widget) {
+	t.Helper()
+
+	got, err := Normalize(view.View())
+	if err != nil {
+		t.Fatalf("failed to normalize the view: %v", err)
+	}
+
+	path := filepath.Join("testdata", strings.ReplaceAll(t.Name(), "/", "_")+".golden")
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the golden file (run with %s=1 to create it): %v", UpdateEnv, err)
+	}
+	if got != string(want) {
+		t.Errorf("view doesn't match %s (run with %s=1 to accept it)\n--- got\n%s--- want\n%s", path, UpdateEnv, got, want)
+	}
+}
+
+// Normalize returns s, the HTML of a view, in a canonical form that doesn't
+// depend on the order widgets were created in or on formatting details:
+// widget IDs are renumbered from oden-1 in document order, attributes are
+// sorted, whitespace in text, styles and classes is collapsed, and each
+// element is put on its own line.
+func Normalize(s string) (string, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	n := &normalizer{ids: make(map[string]string)}
+	for _, node := range nodes {
+		n.write(node, 0)
+	}
+	return n.b.String(), nil
+}
+
+type normalizer struct {
+	b   strings.Builder
+	ids map[string]string
+}
+
+var idPattern = regexp.MustCompile(`\boden-\d+\b`)
+
+// renumber replaces the widget IDs in s, including the ones referred to
+// by selectors in style elements, with their normalized IDs.
+func (n *normalizer) renumber(s string) string {
+	return idPattern.ReplaceAllStringFunc(s, func(s string) string {
+		id, ok := n.ids[s]
+		if !ok {
+			id = fmt.Sprintf("oden-%d", len(n.ids)+1)
+			n.ids[s] = id
+		}
+		return id
+	})
+}
+
+func (n *normalizer) write(node *html.Node, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch node.Type {
+	case html.TextNode:
+		text := n.renumber(collapse(node.Data))
+		if text != "" {
+			fmt.Fprintf(&n.b, "%s%s\n", indent, html.EscapeString(text))
+		}
+	case html.ElementNode:
+		attrs := make([]html.Attribute, len(node.Attr))
+		copy(attrs, node.Attr)
+		sort.Slice(attrs, func(i, j int) bool {
+			return attrs[i].Key < attrs[j].Key
+		})
+
+		fmt.Fprintf(&n.b, "%s<%s", indent, node.Data)
+		for _, a := range attrs {
+			val := a.Val
+			if a.Key == "style" || a.Key == "class" {
+				val = collapse(val)
+			}
+			val = n.renumber(val)
+			fmt.Fprintf(&n.b, ` %s="%s"`, a.Key, html.EscapeString(val))
+		}
+		n.b.WriteString(">\n")
+		if isVoid(node) {
+			return
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			n.write(c, depth+1)
+		}
+		fmt.Fprintf(&n.b, "%s</%s>\n", indent, node.Data)
+	}
+}
+
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func isVoid(node *html.Node) bool {
+	switch node.DataAtom {
+	case atom.Area, atom.Base, atom.Br, atom.Col, atom.Embed, atom.Hr, atom.Img,
+		atom.Input, atom.Link, atom.Meta, atom.Source, atom.Track, atom.Wbr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package odentest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	core "github.com/i2y/oden/core"
+)
+
+// A test binary may define its own -update flag; this one would panic with
+// "flag redefined" if the package defined it.
+var _ = flag.Bool("update", false, "an -update flag of the test binary")
+
+func TestSnapshotUpdate(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	w := newTestWidget(func(id core.WidgetID) string {
+		return fmt.Sprintf(`<p id="%s" class=" a   b ">Hello</p>`, id)
+	})
+	t.Setenv(UpdateEnv, "1")
+	Snapshot(t, w)
+
+	golden, err := os.ReadFile(filepath.Join(dir, "testdata", "TestSnapshotUpdate.golden"))
+	if err != nil {
+		t.Fatalf("golden file not written: %v", err)
+	}
+	want := "<p class=\"a b\" id=\"oden-1\">\n  Hello\n</p>\n"
+	if string(golden) != want {
+		t.Errorf("golden file = %q, want %q", golden, want)
+	}
+
+	t.Setenv(UpdateEnv, "")
+	Snapshot(t, w)
+}
@@ -0,0 +1,169 @@
+package core
+
+import "os"
+
+// AppOptions holds the settings of an App configured by the options passed to NewApp.
+type AppOptions struct {
+	width        int
+	height       int
+	x            int
+	y            int
+	positioned   bool
+	minWidth     int
+	minHeight    int
+	fixedSize    bool
+	addr         string
+	browser      BrowserKind
+	browserPath  string
+	profileDir   string
+	icon         []byte
+	headElements string
+	ids          *IDAllocator
+}
+
+func defaultAppOptions() *AppOptions {
+	return &AppOptions{
+		width:  800,
+		height: 600,
+		addr:   "127.0.0.1:0",
+	}
+}
+
+// WithSize sets the initial size of the main window.
+func WithSize(width, height int) func(*AppOptions) {
+	return func(o *AppOptions) {
+		o.width = width
+		o.height = height
+	}
+}
+
+// WithPosition sets the initial position of the main window on the screen.
+func WithPosition(x, y int) func(*AppOptions) {
+	return func(o *AppOptions) {
+		o.x = x
+		o.y = y
+		o.positioned = true
+	}
+}
+
+// WithMinSize sets the minimum size of the main window.
+// It is honored only by WebView2.
+func WithMinSize(width, height int) func(*AppOptions) {
+	return func(o *AppOptions) {
+		o.minWidth = width
+		o.minHeight = height
+	}
+}
+
+// WithResizable sets whether the main window can be resized by the user.
+// It is honored only by WebView2.
+func WithResizable(resizable bool) func(*AppOptions) {
+	return func(o *AppOptions) {
+		o.fixedSize = !resizable
+	}
+}
+
+// WithAddr sets the address the App's HTTP server listens on.
+// By default it listens on a random port of 127.0.0.1.
+func WithAddr(addr string) func(*AppOptions) {
+	return func(o *AppOptions) {
+		o.addr = addr
+	}
+}
+
+// WithBrowser makes the App use the given kind of browser instead of
+// the first one found in the order of priority.
+// The BrowserEnv environment variable overrides this option.
+func WithBrowser(kind BrowserKind) func(*AppOptions) {
+	return func(o *AppOptions) {
+		o.browser = kind
+	}
+}
+
+// WithBrowserExecutable makes the App use the browser executable at path.
+// If kind is AnyBrowser, the kind of browser is guessed from the name of
+// the executable; unknown executables are assumed to be Chromium-based.
+func WithBrowserExecutable(kind BrowserKind, path string) func(*AppOptions) {
+	return func(o *AppOptions) {
+		o.browser = kind
+		o.browserPath = path
+	}
+}
+
+// WithProfileDir makes the browser use dir as its profile directory,
+// so that settings and storage of the App's pages persist across launches.
+// By default a temporary profile is created for each launch and removed
+// when the App quits. WebView2 doesn't use the profile directory.
+func WithProfileDir(dir string) func(*AppOptions) {
+	return func(o *AppOptions) {
+		o.profileDir = dir
+	}
+}
+
+// WithIcon sets the icon of the App's windows.
+// The icon is served as the favicon of the pages, so any image format
+// supported by the browser can be used.
+func WithIcon(icon []byte) func(*AppOptions) {
+	return func(o *AppOptions) {
+		o.icon = icon
+	}
+}
+
+// WithHeadElements adds HTML elements to the head of the App's pages,
+// in addition to the ones set by SetHeadElements.
+func WithHeadElements(s string) func(*AppOptions) {
+	return func(o *AppOptions) {
+		o.headElements += s
+	}
+}
+
+// WithIDAllocator makes the App allocate the IDs of its widgets with ids.
+// By default each App has its own allocator.
+func WithIDAllocator(ids *IDAllocator) func(*AppOptions) {
+	return func(o *AppOptions) {
+		o.ids = ids
+	}
+}
+
+// profile returns the profile directory of the browser and a function
+// removing the directory if it is a temporary one.
+func (o *AppOptions) profile() (string, func(), error) {
+	if o.profileDir != "" {
+		return o.profileDir, func() {}, os.MkdirAll(o.profileDir, 0o700)
+	}
+
+	dir, err := os.MkdirTemp("", "oden-profile-")
+	if err != nil {
+		return "", nil, err
+	}
+	return dir, func() {
+		os.RemoveAll(dir)
+	}, nil
+}
+
+// windowConfig is passed to a browser to open a window.
+type windowConfig struct {
+	title      string
+	width      int
+	height     int
+	x          int
+	y          int
+	positioned bool
+	minWidth   int
+	minHeight  int
+	fixedSize  bool
+}
+
+func (o *AppOptions) windowConfig(title string) windowConfig {
+	return windowConfig{
+		title:      title,
+		width:      o.width,
+		height:     o.height,
+		x:          o.x,
+		y:          o.y,
+		positioned: o.positioned,
+		minWidth:   o.minWidth,
+		minHeight:  o.minHeight,
+		fixedSize:  o.fixedSize,
+	}
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestProfile(t *testing.T) {
+	t.Run("temporary", func(t *testing.T) {
+		o := defaultAppOptions()
+		dir, remove, err := o.profile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() || !strings.HasPrefix(filepath.Base(dir), "oden-profile-") {
+			t.Fatalf("profile %q isn't a new temporary directory: %v", dir, err)
+		}
+		if runtime.GOOS != "windows" && info.Mode().Perm() != 0o700 {
+			t.Errorf("mode of the profile = %v, want 0700", info.Mode().Perm())
+		}
+
+		remove()
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("temporary profile not removed: %v", err)
+		}
+	})
+
+	t.Run("WithProfileDir", func(t *testing.T) {
+		want := filepath.Join(t.TempDir(), "a", "profile")
+		o := defaultAppOptions()
+		WithProfileDir(want)(o)
+		dir, remove, err := o.profile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dir != want {
+			t.Errorf("profile = %q, want %q", dir, want)
+		}
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			t.Fatalf("profile %q not created: %v", dir, err)
+		}
+		if runtime.GOOS != "windows" && info.Mode().Perm() != 0o700 {
+			t.Errorf("mode of the profile = %v, want 0700", info.Mode().Perm())
+		}
+
+		// The profile persists across launches.
+		remove()
+		if _, err := os.Stat(dir); err != nil {
+			t.Errorf("profile removed: %v", err)
+		}
+	})
+
+	t.Run("WithProfileDir not a directory", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		if err := os.WriteFile(file, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		o := defaultAppOptions()
+		WithProfileDir(file)(o)
+		if _, _, err := o.profile(); err == nil {
+			t.Error("profile() succeeded on a file")
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// overlays is the widget holding the overlays of a window, such as dialogs,
+// which are rendered after its view.
+type overlays struct {
+	id      WidgetID
+	widgets []Widget
+}
+
+func (o *overlays) ID() WidgetID {
+	return o.id
+}
+
+func (o *overlays) View() string {
+	var b strings.Builder
+	for _, w := range o.widgets {
+		b.WriteString(w.View())
+	}
+	return fmt.Sprintf(`<div id="%s" style="display: contents;">%s</div>`, o.id, b.String())
+}
+
+func (o *overlays) Attach(app *App) {}
+
+// AddOverlay shows w on top of the view of the window, e.g. a dialog,
+// until the returned function is called. w is attached by the caller.
+// AddOverlay is to be called on the UI goroutine; see Dispatch.
+func (win *Window) AddOverlay(w Widget) (remove func()) {
+	o := win.overlays
+	o.widgets = append(o.widgets, w)
+	win.app.PostUpdate(o)
+
+	return func() {
+		for i, c := range o.widgets {
+			if c == w {
+				o.widgets = append(o.widgets[:i:i], o.widgets[i+1:]...)
+				win.app.PostUpdate(o)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"os/exec"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// terminateTimeout is how long a browser process is given to exit
+// gracefully before it is killed.
+const terminateTimeout = 5 * time.Second
+
+// process is a browser process started by an App.
+type process struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+func startProcess(name string, args ...string) (*process, error) {
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := &process{
+		cmd:  cmd,
+		done: make(chan struct{}),
+	}
+	go func() {
+		cmd.Wait()
+		close(p.done)
+	}()
+	return p, nil
+}
+
+// wait blocks until the process exits.
+func (p *process) wait() {
+	<-p.done
+}
+
+// terminate asks the process to exit, kills it if it doesn't exit in time,
+// and waits for it to exit.
+func (p *process) terminate() {
+	select {
+	case <-p.done:
+		return
+	default:
+	}
+
+	if runtime.GOOS == "windows" {
+		p.cmd.Process.Kill()
+	} else {
+		p.cmd.Process.Signal(syscall.SIGTERM)
+	}
+
+	select {
+	case <-p.done:
+	case <-time.After(terminateTimeout):
+		p.cmd.Process.Kill()
+		<-p.done
+	}
+}
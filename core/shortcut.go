@@ -0,0 +1,201 @@
+package core
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"unicode"
+)
+
+// shortcut is a key combination parsed from an accelerator such as "Ctrl+S".
+type shortcut struct {
+	ID        string    `json:"id"`
+	Key       string    `json:"key"`
+	Modifiers Modifiers `json:"modifiers"`
+}
+
+type shortcutHandler struct {
+	handler func(ev Event)
+}
+
+// goos is the platform whose modifiers are used by the shortcuts,
+// a variable so that tests can pretend to run on another one.
+var goos = runtime.GOOS
+
+// primaryModifier is the modifier of the usual shortcuts of the platform,
+// such as Cmd+S on macOS and Ctrl+S elsewhere.
+func primaryModifier() Modifiers {
+	if goos == "darwin" {
+		return MetaKey
+	}
+	return CtrlKey
+}
+
+var keyAliases = map[string]string{
+	"esc":        "Escape",
+	"escape":     "Escape",
+	"enter":      "Enter",
+	"return":     "Enter",
+	"tab":        "Tab",
+	"space":      " ",
+	"plus":       "+",
+	"backspace":  "Backspace",
+	"delete":     "Delete",
+	"del":        "Delete",
+	"insert":     "Insert",
+	"ins":        "Insert",
+	"home":       "Home",
+	"end":        "End",
+	"pageup":     "PageUp",
+	"pgup":       "PageUp",
+	"pagedown":   "PageDown",
+	"pgdn":       "PageDown",
+	"up":         "ArrowUp",
+	"arrowup":    "ArrowUp",
+	"down":       "ArrowDown",
+	"arrowdown":  "ArrowDown",
+	"left":       "ArrowLeft",
+	"arrowleft":  "ArrowLeft",
+	"right":      "ArrowRight",
+	"arrowright": "ArrowRight",
+}
+
+func init() {
+	for i := 1; i <= 24; i++ {
+		f := fmt.Sprintf("F%d", i)
+		keyAliases[strings.ToLower(f)] = f
+	}
+}
+
+// parseShortcut parses an accelerator: modifiers and a key joined by "+".
+func parseShortcut(accelerator string) (shortcut, error) {
+	var s shortcut
+	parts := strings.Split(accelerator, "+")
+	// A trailing "+" is the plus key, as in "Ctrl++".
+	if len(parts) > 1 && parts[len(parts)-1] == "" && parts[len(parts)-2] == "" {
+		parts = append(parts[:len(parts)-2], "+")
+	}
+
+	for i, part := range parts {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if i < len(parts)-1 {
+			switch name {
+			case "ctrl", "cmdorctrl", "commandorcontrol", "mod":
+				s.Modifiers |= primaryModifier()
+			case "control":
+				s.Modifiers |= CtrlKey
+			case "cmd", "command", "meta", "super":
+				s.Modifiers |= MetaKey
+			case "alt", "option":
+				s.Modifiers |= AltKey
+			case "shift":
+				s.Modifiers |= ShiftKey
+			default:
+				return shortcut{}, fmt.Errorf("unknown modifier %q in the shortcut %q", part, accelerator)
+			}
+			continue
+		}
+
+		if key, ok := keyAliases[name]; ok {
+			s.Key = key
+		} else if len([]rune(name)) == 1 {
+			s.Key = name
+		} else {
+			return shortcut{}, fmt.Errorf("unknown key %q in the shortcut %q", part, accelerator)
+		}
+	}
+	// A symbol tells by itself whether Shift is pressed, which depends on
+	// the keyboard layout, so Shift is ignored: "Ctrl++" is "Ctrl+Shift+=" on
+	// a US keyboard.
+	if isSymbol(s.Key) {
+		s.Modifiers &^= ShiftKey
+	}
+	s.ID = fmt.Sprintf("%d:%s", s.Modifiers, s.Key)
+	return s, nil
+}
+
+// isSymbol reports whether key is a character typed with or without Shift
+// depending on the keyboard layout, i.e. neither a letter, a digit nor a space.
+func isSymbol(key string) bool {
+	r := []rune(key)
+	return len(r) == 1 && !unicode.IsLetter(r[0]) && !unicode.IsDigit(r[0]) && !unicode.IsSpace(r[0])
+}
+
+// AddShortcut calls handler when the keys of accelerator are pressed in any
+// window of the App, instead of the default action of the browser, and returns
+// a function removing the handler. The Target of the event passed to handler is nil.
+//
+// An accelerator is modifiers and a key joined by "+", e.g. "Ctrl+S",
+// "Shift+F5" or "Escape". Ctrl is the Command key on macOS and the Control key
+// elsewhere; use Control or Cmd to refer to a specific key. The other modifiers
+// are Alt and Shift. Keys are characters or names such as Enter, Escape, Tab,
+// Space, Delete, Home, Up or F1 to F24. Shift is ignored with symbols, which
+// are matched whatever the keys typing them, e.g. "Ctrl++" or "Ctrl+?".
+// AddShortcut panics if accelerator is malformed.
+func (app *App) AddShortcut(accelerator string, handler func(ev Event)) func() {
+	s, err := parseShortcut(accelerator)
+	if err != nil {
+		panic(err)
+	}
+
+	h := &shortcutHandler{handler}
+	app.mu.Lock()
+	app.shortcuts[s] = append(app.shortcuts[s], h)
+	app.mu.Unlock()
+	app.sendShortcuts()
+
+	return func() {
+		app.mu.Lock()
+		handlers := app.shortcuts[s]
+		for i, x := range handlers {
+			if x == h {
+				app.shortcuts[s] = append(handlers[:i:i], handlers[i+1:]...)
+				break
+			}
+		}
+		if len(app.shortcuts[s]) == 0 {
+			delete(app.shortcuts, s)
+		}
+		app.mu.Unlock()
+		app.sendShortcuts()
+	}
+}
+
+// shortcutsCommand returns the command making a page prevent the default
+// action of the shortcuts and send them to the App.
+func (app *App) shortcutsCommand() string {
+	app.mu.Lock()
+	shortcuts := make([]shortcut, 0, len(app.shortcuts))
+	for s := range app.shortcuts {
+		shortcuts = append(shortcuts, s)
+	}
+	app.mu.Unlock()
+
+	return command(map[string]interface{}{
+		"command":   "shortcuts",
+		"shortcuts": shortcuts,
+	})
+}
+
+func (app *App) sendShortcuts() {
+	app.broadcast(app.shortcutsCommand())
+}
+
+func (app *App) runShortcut(ev *rawEvent) {
+	var handlers []*shortcutHandler
+	app.mu.Lock()
+	for s, hs := range app.shortcuts {
+		if s.ID == ev.Shortcut {
+			handlers = append(handlers, hs...)
+		}
+	}
+	app.mu.Unlock()
+
+	for _, h := range handlers {
+		h.handler(&actualEvent{
+			eventName: "shortcut",
+			props:     ev.Props,
+			detail:    ev.Detail,
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package core
+
+import "testing"
+
+func TestParseShortcut(t *testing.T) {
+	defer func(old string) { goos = old }(goos)
+	goos = "linux"
+
+	tests := []struct {
+		accelerator string
+		key         string
+		modifiers   Modifiers
+	}{
+		{"Ctrl+S", "s", CtrlKey},
+		{"ctrl + shift + s", "s", CtrlKey | ShiftKey},
+		{"Alt+F4", "F4", AltKey},
+		{"Escape", "Escape", 0},
+		{"Cmd+Space", " ", MetaKey},
+		{"Control+Up", "ArrowUp", CtrlKey},
+		{"+", "+", 0},
+		{"Ctrl++", "+", CtrlKey},
+		{"Ctrl+Plus", "+", CtrlKey},
+		// Shift goes with symbols, whichever keys type them.
+		{"Ctrl+Shift++", "+", CtrlKey},
+		{"Shift+?", "?", 0},
+		{"Ctrl+Shift+!", "!", CtrlKey},
+		// Shift is kept with letters and digits, which are matched by code.
+		{"Shift+1", "1", ShiftKey},
+		{"Shift+A", "a", ShiftKey},
+	}
+	for _, tt := range tests {
+		s, err := parseShortcut(tt.accelerator)
+		if err != nil {
+			t.Errorf("parseShortcut(%q) failed: %v", tt.accelerator, err)
+			continue
+		}
+		if s.Key != tt.key || s.Modifiers != tt.modifiers {
+			t.Errorf("parseShortcut(%q) = %q with modifiers %d, want %q with %d", tt.accelerator, s.Key, s.Modifiers, tt.key, tt.modifiers)
+		}
+	}
+
+	for _, accelerator := range []string{"", "Ctrl+", "Ctrl+Shift", "Foo+S", "Ctrl+Nope", "S+Ctrl", "Ctrl+ "} {
+		if _, err := parseShortcut(accelerator); err == nil {
+			t.Errorf("parseShortcut(%q) succeeded, want an error", accelerator)
+		}
+	}
+}
+
+func TestParseShortcutOnMacOS(t *testing.T) {
+	defer func(old string) { goos = old }(goos)
+	goos = "darwin"
+
+	tests := []struct {
+		accelerator string
+		modifiers   Modifiers
+	}{
+		{"Ctrl+S", MetaKey},
+		{"CmdOrCtrl+S", MetaKey},
+		{"Mod+Shift+S", MetaKey | ShiftKey},
+		{"Control+S", CtrlKey},
+		{"Cmd+S", MetaKey},
+	}
+	for _, tt := range tests {
+		s, err := parseShortcut(tt.accelerator)
+		if err != nil {
+			t.Errorf("parseShortcut(%q) failed: %v", tt.accelerator, err)
+			continue
+		}
+		if s.Modifiers != tt.modifiers {
+			t.Errorf("parseShortcut(%q) modifiers = %d, want %d", tt.accelerator, s.Modifiers, tt.modifiers)
+		}
+	}
+}
+
+func TestShortcutsAreMatchedByID(t *testing.T) {
+	app, conn := newTestApp(t, newTestWidget(func(id WidgetID) string {
+		return `<div id="` + id.String() + `"></div>`
+	}))
+	var zoomed int
+	remove := app.AddShortcut("Ctrl++", func(Event) {
+		zoomed++
+	})
+
+	for _, accelerator := range []string{"Ctrl++", "Ctrl+Shift++", "Ctrl+Plus"} {
+		if err := conn.Shortcut(accelerator); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if zoomed != 3 {
+		t.Errorf("zoomed %d times, want 3", zoomed)
+	}
+
+	remove()
+	conn.Shortcut("Ctrl++")
+	if zoomed != 3 {
+		t.Errorf("removed shortcut run")
+	}
+}
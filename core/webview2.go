@@ -3,11 +3,18 @@
 
 package core
 
-type WebView2 struct{}
+type webView2 struct{}
 
-func (wv *WebView2) open(title string, port, width, height int) {
+func (wv *webView2) open(url string, config *windowConfig) error {
+	return nil
+}
+
+func (wv *webView2) wait() {
+}
+
+func (wv *webView2) terminate() {
 }
 
-func detectWebview2() *WebView2 {
+func detectWebview2() *webView2 {
 	return nil
 }
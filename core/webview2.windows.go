@@ -4,25 +4,99 @@
 package core
 
 import (
-	"fmt"
+	"errors"
+	"runtime"
+	"sync"
 	"syscall"
 
 	"github.com/jchv/go-webview2"
 )
 
 type webView2 struct {
-	wv webview2.WebView
+	mu    sync.Mutex
+	wv    webview2.WebView
+	views map[webview2.WebView]struct{}
 }
 
-func (wv *webView2) open(title string, port, width, height int) {
-	wv.wv.SetTitle(title)
-	wv.wv.SetSize(width, height, webview2.HintNone)
-	wv.wv.Navigate(fmt.Sprintf("http://localhost:%d", port))
-	defer wv.wv.Destroy()
-	wv.wv.Run()
-	return
+// open shows url in a WebView2 window. The first call uses the WebView
+// created by detectWebview2; later calls create a new WebView on their
+// own locked OS thread, since each WebView runs its own message loop.
+// open returns when the window is closed. WebView2 doesn't use the
+// profile directory passed to other browsers.
+func (wv *webView2) open(url string, config *windowConfig) error {
+	wv.mu.Lock()
+	w := wv.wv
+	wv.wv = nil
+	wv.mu.Unlock()
+
+	if w == nil {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		w = webview2.New(false)
+		if w == nil {
+			return errors.New("failed to create a WebView2 window")
+		}
+	}
+
+	wv.mu.Lock()
+	wv.views[w] = struct{}{}
+	wv.mu.Unlock()
+	defer func() {
+		wv.mu.Lock()
+		delete(wv.views, w)
+		wv.mu.Unlock()
+	}()
+
+	w.Bind("odenCloseWindow", func() error {
+		w.Terminate()
+		return nil
+	})
+	w.SetTitle(config.title)
+	if config.fixedSize {
+		w.SetSize(config.width, config.height, webview2.HintFixed)
+	} else {
+		if config.minWidth > 0 || config.minHeight > 0 {
+			w.SetSize(config.minWidth, config.minHeight, webview2.HintMin)
+		}
+		w.SetSize(config.width, config.height, webview2.HintNone)
+	}
+	if config.positioned {
+		setWindowPos.Call(
+			uintptr(w.Window()),
+			0,
+			uintptr(config.x),
+			uintptr(config.y),
+			0,
+			0,
+			swpNoSize|swpNoZOrder,
+		)
+	}
+	w.Navigate(url)
+	defer w.Destroy()
+	w.Run()
+	return nil
+}
+
+// wait returns immediately since open blocks until the main window is closed.
+func (wv *webView2) wait() {
 }
 
+func (wv *webView2) terminate() {
+	wv.mu.Lock()
+	defer wv.mu.Unlock()
+
+	for w := range wv.views {
+		w.Terminate()
+	}
+}
+
+const (
+	swpNoSize   = 0x0001
+	swpNoZOrder = 0x0004
+)
+
+var setWindowPos = syscall.NewLazyDLL("user32").NewProc("SetWindowPos")
+
 func detectWebview2() *webView2 {
 	dll := syscall.MustLoadDLL("user32")
 	if proc, err := dll.FindProc("SetProcessDpiAwarenessContext"); err == nil {
@@ -33,5 +107,8 @@ func detectWebview2() *webView2 {
 	if wv == nil {
 		return nil
 	}
-	return &webView2{wv: wv}
+	return &webView2{
+		wv:    wv,
+		views: make(map[webview2.WebView]struct{}),
+	}
 }
@@ -0,0 +1,330 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+)
+
+// Window is a browser window showing a widget tree of an App.
+// Each window is served at its own path, and every update of the window is
+// broadcast to all the WebSocket clients connected to that path.
+type Window struct {
+	app     *App
+	id      int
+	config  windowConfig
+	view    Widget
+	hub     *hub
+	done    chan struct{}
+	mu      sync.Mutex
+	opened  bool
+	closed  bool
+	widgets map[WidgetID]struct{}
+
+	// overlays are shown on top of the view; see AddOverlay.
+	overlays *overlays
+
+	// dom is a copy of the body of the page, from which patches are computed.
+	dom *html.Node
+	// runs are the locations of the nodes last rendered by the widgets
+	// patched since the page was synced.
+	runs map[WidgetID]run
+}
+
+var closeCommand = command(map[string]interface{}{
+	"command": "close",
+})
+
+func command(cmd map[string]interface{}) string {
+	b, err := json.Marshal(cmd)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+var widgetIDPattern = regexp.MustCompile(`id="oden-(\d+)"`)
+
+func (app *App) newWindow(config windowConfig, view Widget) *Window {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	win := &Window{
+		app:      app,
+		id:       app.windowID,
+		config:   config,
+		view:     view,
+		hub:      newHub(),
+		done:     make(chan struct{}),
+		widgets:  make(map[WidgetID]struct{}),
+		overlays: &overlays{},
+		dom:      newBody(),
+		runs:     make(map[WidgetID]run),
+	}
+	app.windowID++
+	app.windows[win.id] = win
+	return win
+}
+
+// OpenWindow opens a new window showing view.
+// Windows opened before the App runs are shown once the main window is connected.
+func (app *App) OpenWindow(title string, width, height int, view Widget) *Window {
+	config := app.options.windowConfig(title)
+	config.width = width
+	config.height = height
+	config.positioned = false
+	win := app.newWindow(config, view)
+	win.attach()
+
+	app.mu.Lock()
+	started := app.started
+	app.mu.Unlock()
+	if started {
+		win.open()
+	}
+	return win
+}
+
+// attach attaches the view of the window to the App.
+func (win *Window) attach() {
+	win.view.Attach(win.app)
+	win.overlays.id = win.app.ids.Next()
+}
+
+// MainWindow returns the window showing the view passed to NewApp.
+func (app *App) MainWindow() *Window {
+	return app.main
+}
+
+func (app *App) openPendingWindows() {
+	app.mu.Lock()
+	if app.started {
+		app.mu.Unlock()
+		return
+	}
+	app.started = true
+	var pending []*Window
+	for _, win := range app.windows {
+		if win != app.main {
+			pending = append(pending, win)
+		}
+	}
+	app.mu.Unlock()
+
+	for _, win := range pending {
+		win.open()
+	}
+}
+
+func (app *App) lookupWindow(path string) (*Window, string) {
+	parts := strings.SplitN(strings.TrimPrefix(path, "/windows/"), "/", 2)
+	id, err := strconv.Atoi(parts[0])
+	if err != nil || id == app.main.id {
+		return nil, ""
+	}
+
+	app.mu.Lock()
+	win := app.windows[id]
+	app.mu.Unlock()
+
+	rest := ""
+	if len(parts) == 2 {
+		rest = parts[1]
+	}
+	return win, rest
+}
+
+// windowsShowing returns the windows whose page contains the widget with the given ID.
+// All the windows are returned if the widget has not been rendered in any window yet.
+func (app *App) windowsShowing(id WidgetID) []*Window {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	var all, showing []*Window
+	for _, win := range app.windows {
+		all = append(all, win)
+		if win.shows(id) {
+			showing = append(showing, win)
+		}
+	}
+	if len(showing) == 0 {
+		return all
+	}
+	return showing
+}
+
+func (app *App) shownInAnyWindow(id WidgetID) bool {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	for _, win := range app.windows {
+		if win.shows(id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (app *App) closeWindow(win *Window) {
+	win.mu.Lock()
+	if win.closed {
+		win.mu.Unlock()
+		return
+	}
+	win.closed = true
+	win.mu.Unlock()
+
+	app.mu.Lock()
+	delete(app.windows, win.id)
+	app.mu.Unlock()
+	close(win.done)
+
+	// The widgets of the window can't receive events anymore,
+	// unless they are shown in another window too.
+	for _, id := range win.untrack() {
+		if !app.shownInAnyWindow(id) {
+			app.bus.unsubscribeAll(id)
+		}
+	}
+	// The view stops updating the window, unless another window shows it.
+	if d, ok := win.view.(Detacher); ok && !app.showsView(win.view) {
+		app.call(d.Detach)
+	}
+	app.scheduleFlush()
+
+	if win == app.main {
+		app.quit()
+	}
+}
+
+// showsView reports whether a window of the App shows view.
+func (app *App) showsView(view Widget) bool {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	for _, win := range app.windows {
+		if win.view == view {
+			return true
+		}
+	}
+	return false
+}
+
+func (win *Window) Title() string {
+	return win.config.title
+}
+
+// Close closes the window. Closing the main window quits the App.
+func (win *Window) Close() {
+	win.send(closeCommand)
+	win.app.closeWindow(win)
+}
+
+func (win *Window) path() string {
+	if win == win.app.main {
+		return "/"
+	}
+	return fmt.Sprintf("/windows/%d/", win.id)
+}
+
+func (win *Window) open() {
+	win.mu.Lock()
+	if win.opened || win.closed {
+		win.mu.Unlock()
+		return
+	}
+	win.opened = true
+	win.mu.Unlock()
+
+	if win == win.app.main {
+		win.launch()
+		return
+	}
+	go win.launch()
+}
+
+func (win *Window) launch() {
+	err := win.app.browser.open(win.app.windowURL(win), &win.config)
+	if err != nil {
+		win.app.reportError(fmt.Errorf("failed to open the window %q: %w", win.config.title, err))
+	}
+}
+
+// broadcast sends msg to every client connected to any window.
+func (app *App) broadcast(msg string) {
+	app.mu.Lock()
+	windows := make([]*Window, 0, len(app.windows))
+	for _, win := range app.windows {
+		windows = append(windows, win)
+	}
+	app.mu.Unlock()
+
+	for _, win := range windows {
+		win.send(msg)
+	}
+}
+
+// send sends msg to every client connected to the window.
+// It doesn't block even if no client is connected.
+func (win *Window) send(msg string) {
+	win.hub.broadcast(msg)
+}
+
+// syncMessage returns a message replacing the whole page with the current view.
+// The pending updates are sent first, so that the pages already connected
+// are in sync with the copy of the page kept by the window.
+func (win *Window) syncMessage() string {
+	win.app.Flush()
+	view := win.View()
+	win.track(view)
+	win.resetDOM(view)
+	return command(map[string]interface{}{
+		"command": "sync",
+		"html":    view,
+	})
+}
+
+// View returns the HTML of the page of the window:
+// the view of the window followed by its overlays.
+// View is to be called on the UI goroutine; see App.Dispatch.
+func (win *Window) View() string {
+	return win.view.View() + win.overlays.View()
+}
+
+// track records the widgets contained in html, the whole page of the window,
+// as the ones shown in the window. Patches keep the record up to date.
+func (win *Window) track(html string) {
+	win.mu.Lock()
+	defer win.mu.Unlock()
+
+	win.widgets = make(map[WidgetID]struct{})
+	for _, id := range containedWidgets(html) {
+		win.widgets[id] = struct{}{}
+	}
+}
+
+// untrack forgets the widgets shown in the window and returns them.
+func (win *Window) untrack() []WidgetID {
+	win.mu.Lock()
+	defer win.mu.Unlock()
+
+	ids := make([]WidgetID, 0, len(win.widgets))
+	for id := range win.widgets {
+		ids = append(ids, id)
+	}
+	win.widgets = make(map[WidgetID]struct{})
+	return ids
+}
+
+func (win *Window) shows(id WidgetID) bool {
+	win.mu.Lock()
+	defer win.mu.Unlock()
+
+	_, ok := win.widgets[id]
+	return ok
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWindowTracksRenderedWidgets(t *testing.T) {
+	shown := true
+	child := newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<b id="%s">child</b>`, id)
+	})
+	parent := newTestWidget(func(id WidgetID) string {
+		if !shown {
+			return fmt.Sprintf(`<div id="%s"></div>`, id)
+		}
+		return fmt.Sprintf(`<div id="%s">%s</div>`, id, child.View())
+	}, child)
+	app, conn := newTestApp(t, parent)
+	win := app.MainWindow()
+
+	if !win.shows(parent.ID()) || !win.shows(child.ID()) {
+		t.Fatal("rendered widgets not tracked")
+	}
+
+	app.call(func() {
+		shown = false
+		app.PostUpdate(parent)
+	})
+	receiveAll(app, conn)
+	if win.shows(child.ID()) {
+		t.Error("widget dropped from the view still tracked")
+	}
+	if !win.shows(parent.ID()) {
+		t.Error("re-rendered widget not tracked")
+	}
+
+	app.call(func() {
+		shown = true
+		app.PostUpdate(parent)
+	})
+	receiveAll(app, conn)
+	if !win.shows(child.ID()) {
+		t.Error("widget added to the view not tracked")
+	}
+}
+
+func TestClosedWindowForgetsWidgets(t *testing.T) {
+	main := newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<div id="%s">main</div>`, id)
+	})
+	shared := newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<i id="%s">shared</i>`, id)
+	})
+	button := newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<button id="%s">OK</button>`, id)
+	})
+	app, _ := newTestApp(t, main)
+	shared.Attach(app)
+
+	other := app.OpenWindow("other", 100, 100, newTestWidget(func(id WidgetID) string {
+		return fmt.Sprintf(`<div id="%s">%s%s</div>`, id, button.View(), shared.View())
+	}, button))
+	otherConn := other.Connect()
+	defer otherConn.Close()
+	// The shared widget is shown by the main window too.
+	app.MainWindow().track(main.View() + shared.View())
+
+	app.AddEventHandler(button, "click", func(Event) {})
+	app.AddEventHandler(shared, "click", func(Event) {})
+	if !other.shows(button.ID()) {
+		t.Fatal("widget of the other window not tracked")
+	}
+
+	other.Close()
+	if other.shows(button.ID()) {
+		t.Error("widget of a closed window still tracked")
+	}
+	if len(app.bus.handlers[button.ID()]) != 0 {
+		t.Error("handlers of a widget of a closed window still registered")
+	}
+	if len(app.bus.handlers[shared.ID()]) == 0 {
+		t.Error("handlers of a widget shown in another window removed")
+	}
+}
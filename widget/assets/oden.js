@@ -0,0 +1,142 @@
+// Some properties of Shoelace components, such as arrays and functions,
+// can't be given by attributes. Oden renders them in data attributes instead,
+// which are applied to the properties whenever they change.
+const dataProperties = {
+  // The value of a multiple sl-select, as a space separated list.
+  "sl-select": {
+    "data-values": (select, values) => {
+      select.value = values.split(" ").filter((v) => v != "");
+    },
+  },
+  // The labels of the values of an sl-range from its min to its max by its step, as JSON.
+  "sl-range": {
+    "data-tooltip-labels": (range, labels) => {
+      labels = JSON.parse(labels);
+      range.tooltipFormatter = (value) => {
+        let label = labels[Math.round((value - range.min) / range.step)];
+        return label === undefined ? String(value) : label;
+      };
+    },
+  },
+  // The name of the panel an sl-tab-group shows, which it only reads from
+  // the active attributes of its tabs when it is connected.
+  "sl-tab-group": {
+    "data-active": (group, panel) => {
+      group.updateComplete.then(() => group.show(panel));
+    },
+  },
+  // The swatches of an sl-color-picker, as JSON.
+  "sl-color-picker": {
+    "data-swatches": (picker, swatches) => {
+      picker.swatches = JSON.parse(swatches);
+    },
+  },
+};
+
+function applyDataProperties(el, names) {
+  let props = dataProperties[el.localName];
+  if (!props) {
+    return;
+  }
+  for (const name of names || Object.keys(props)) {
+    if (props[name] && el.hasAttribute(name)) {
+      let value = el.getAttribute(name);
+      customElements.whenDefined(el.localName).then(() => props[name](el, value));
+    }
+  }
+}
+
+new MutationObserver((mutations) => {
+  for (const m of mutations) {
+    if (m.type == "attributes") {
+      applyDataProperties(m.target, [m.attributeName]);
+      continue;
+    }
+    for (const node of m.addedNodes) {
+      if (node.nodeType != Node.ELEMENT_NODE) {
+        continue;
+      }
+      applyDataProperties(node);
+      node.querySelectorAll(Object.keys(dataProperties).join(",")).forEach((el) => applyDataProperties(el));
+    }
+  }
+}).observe(document, {
+  subtree: true,
+  childList: true,
+  attributes: true,
+  attributeFilter: Object.values(dataProperties).flatMap(Object.keys),
+});
+
+// Menus: a submenu is an sl-dropdown in the suffix of its menu item, and a
+// context menu is an sl-dropdown moved to the pointer; see menu.go and
+// contextmenu.go.
+
+// showMenu opens dropdown and focuses the first item of its menu, so that
+// the menu can be used with the keyboard.
+function showMenu(dropdown) {
+  customElements.whenDefined("sl-dropdown").then(async () => {
+    await dropdown.show();
+    let menu = dropdown.querySelector(":scope > sl-menu");
+    let items = menu ? menu.getAllItems({ includeDisabled: false }) : [];
+    if (items.length) {
+      menu.setCurrentItem(items[0]);
+      items[0].focus();
+    }
+  });
+}
+
+function submenuOf(item) {
+  return item ? item.querySelector(":scope > sl-dropdown.oden-submenu") : null;
+}
+
+// Selecting an item with a submenu opens the submenu and keeps the menu open.
+document.addEventListener(
+  "sl-select",
+  (e) => {
+    let submenu = submenuOf(e.detail && e.detail.item);
+    if (submenu) {
+      e.stopPropagation();
+      showMenu(submenu);
+    }
+  },
+  true,
+);
+
+document.addEventListener(
+  "keydown",
+  (e) => {
+    if (e.key != "ArrowRight" || e.target.localName != "sl-menu-item") {
+      return;
+    }
+    let submenu = submenuOf(e.target);
+    if (submenu) {
+      e.preventDefault();
+      showMenu(submenu);
+    }
+  },
+  true,
+);
+
+// odenSubmenuKeyDown keeps key presses in a submenu from the menu of its
+// item, and closes the submenu on the left arrow key and the Escape key.
+function odenSubmenuKeyDown(e) {
+  let dropdown = e.currentTarget;
+  e.stopPropagation();
+  if (e.key == "ArrowLeft" || e.key == "Escape") {
+    e.preventDefault();
+    dropdown.hide();
+    dropdown.closest("sl-menu-item").focus();
+  }
+}
+
+document.addEventListener("contextmenu", (e) => {
+  let target = e.target.closest ? e.target.closest("[data-context-menu]") : null;
+  let dropdown = target ? target.querySelector(":scope > sl-dropdown.oden-context-menu") : null;
+  if (!dropdown || dropdown.contains(e.target)) {
+    return;
+  }
+  e.preventDefault();
+  dropdown.style.left = e.clientX + "px";
+  dropdown.style.top = e.clientY + "px";
+  showMenu(dropdown);
+});
@@ -3,8 +3,7 @@ package widget
 import (
 	"embed"
 	"fmt"
-
-	"github.com/asaskevich/EventBus"
+	"sync"
 
 	core "github.com/i2y/oden/core"
 )
@@ -40,7 +39,17 @@ type Widget interface {
 	Padding(n int) Widget
 	Margin(n int) Widget
 	OnClick(func(ev core.Event)) Widget
+	OnDoubleClick(func(ev core.Event)) Widget
+	OnMouseDown(func(ev core.Event)) Widget
+	OnMouseUp(func(ev core.Event)) Widget
+	OnKeyDown(func(ev core.Event)) Widget
+	OnKeyUp(func(ev core.Event)) Widget
+	OnFocus(func(ev core.Event)) Widget
+	OnBlur(func(ev core.Event)) Widget
+	OnInput(func(ev core.Event)) Widget
 	OnChange(func(ev core.Event)) Widget
+	On(event string, handler func(ev core.Event)) (cancel func())
+	Key(key string) Widget
 }
 
 type SizePolicy int
@@ -106,6 +115,30 @@ func (va VerticalAlign) String() string {
 	return ""
 }
 
+// Placement is the edge of a window or a widget where something is placed.
+type Placement int
+
+const (
+	TopPlacement Placement = iota
+	EndPlacement
+	BottomPlacement
+	StartPlacement
+)
+
+func (p Placement) String() string {
+	switch p {
+	case TopPlacement:
+		return "top"
+	case EndPlacement:
+		return "end"
+	case BottomPlacement:
+		return "bottom"
+	case StartPlacement:
+		return "start"
+	}
+	return "end"
+}
+
 type Color struct {
 	name      string
 	swatchNum int
@@ -218,7 +251,13 @@ func (s *OtherStyle) String() string {
 
 type Base struct {
 	id           core.WidgetID
+	key          string
+	handlers     []*eventHandler
+	setHandlers  map[string]func()
+	models       []EventPublisher
+	cancels      []func()
 	attached     bool
+	receiving    bool
 	app          *core.App
 	widget       Widget
 	actualWidget Widget
@@ -232,7 +271,6 @@ type Base struct {
 
 func NewBase() Base {
 	return Base{
-		id:         core.NewWidgetID(),
 		attached:   false,
 		sizePolicy: Expanding,
 		textStyle: &TextStyle{
@@ -245,64 +283,281 @@ func NewBase() Base {
 	}
 }
 
+// ID returns the ID of the widget. The ID is allocated by the App when the
+// widget is attached; a widget rendered before being attached gets an ID
+// from core.NewWidgetID.
 func (b *Base) ID() core.WidgetID {
+	if b.id == 0 {
+		if b.key != "" {
+			b.id = core.KeyedWidgetID(b.key)
+		} else {
+			b.id = core.NewWidgetID()
+		}
+	}
 	return b.id
 }
 
+// Key gives the widget an ID derived from key instead of one allocated in
+// order of attachment, so that the ID stays the same across runs and
+// rebuilds of the widget tree. Keys must be unique within an App.
+// Key panics if the widget is attached, since the page and the event
+// handlers of the widget refer to its current ID.
+func (b *Base) Key(key string) Widget {
+	if b.attached {
+		panic(fmt.Sprintf("widget key %q set after the widget was attached", key))
+	}
+	b.key = key
+	b.id = 0
+	return b.widget
+}
+
 func (b *Base) View() string {
 	return ""
 }
 
+// Attach attaches the widget to the App, registering its event handlers
+// and listening to its models.
 func (b *Base) Attach(a *core.App) {
+	if b.attached {
+		b.unsubscribe()
+	}
 	b.attached = true
 	b.app = a
+	if b.key != "" {
+		b.id = a.IDs().Key(b.key)
+	} else {
+		b.id = a.IDs().Next()
+	}
+	for _, h := range b.handlers {
+		h.cancel = a.AddEventHandler(b.widget, h.event, h.handler)
+	}
+	for _, m := range b.models {
+		b.cancels = append(b.cancels, m.AddListener(b.widget))
+	}
 }
 
+// Detach removes the event handlers of the widget from the App and stops
+// listening to its models, so that a removed widget can be garbage collected.
+// The handlers and models are registered again if the widget is attached again.
 func (b *Base) Detach() {
+	if b.attached {
+		b.unsubscribe()
+	}
 	b.attached = false
 }
 
+func (b *Base) unsubscribe() {
+	for _, h := range b.handlers {
+		if h.cancel != nil {
+			h.cancel()
+			h.cancel = nil
+		}
+	}
+	for _, cancel := range b.cancels {
+		cancel()
+	}
+	b.cancels = nil
+}
+
+// listen makes the widget re-render every time one of models is notified
+// while the widget is attached.
+func (b *Base) listen(models ...EventPublisher) {
+	b.models = append(b.models, models...)
+	if b.attached {
+		for _, m := range models {
+			b.cancels = append(b.cancels, m.AddListener(b.widget))
+		}
+	}
+}
+
 func (b *Base) SetWidget(w Widget) {
 	b.widget = w
 }
 
 func (b *Base) Update() {
-	if b.attached {
+	if b.attached && !b.receiving {
 		b.app.PostUpdate(b.widget)
 	}
 }
 
-func (b *Base) OnClick(handler func(ev core.Event)) Widget {
-	core.AddEventHandler(b.widget, "click", handler)
+// receive runs f, which applies a value reported by the browser to a model
+// bound to the widget. The widget itself is not re-rendered while f runs
+// because the browser already shows the value, but other widgets listening
+// to the model are.
+func (b *Base) receive(f func()) {
+	b.receiving = true
+	defer func() {
+		b.receiving = false
+		if b.attached {
+			b.app.PostShown(b.widget)
+		}
+	}()
+	f()
+}
+
+type eventHandler struct {
+	event   string
+	handler func(ev core.Event)
+	cancel  func()
+}
+
+// On registers handler for the given event of the widget and returns
+// a function removing it. The handler is active while the widget is attached.
+func (b *Base) On(event string, handler func(ev core.Event)) func() {
+	h := &eventHandler{
+		event:   event,
+		handler: handler,
+	}
+	b.handlers = append(b.handlers, h)
+	if b.attached {
+		h.cancel = b.app.AddEventHandler(b.widget, event, handler)
+	}
+	return func() {
+		for i, x := range b.handlers {
+			if x == h {
+				b.handlers = append(b.handlers[:i:i], b.handlers[i+1:]...)
+				break
+			}
+		}
+		if h.cancel != nil {
+			h.cancel()
+			h.cancel = nil
+		}
+	}
+}
+
+// setHandler sets the handler of the event set by the On* methods,
+// replacing the one set by a previous call.
+func (b *Base) setHandler(event string, handler func(ev core.Event)) Widget {
+	if b.setHandlers == nil {
+		b.setHandlers = make(map[string]func())
+	}
+	if cancel, ok := b.setHandlers[event]; ok {
+		cancel()
+	}
+	b.setHandlers[event] = b.On(event, handler)
 	return b.widget
 }
 
+// OnClick sets the click handler of the widget,
+// replacing the one set by a previous call. The same applies to
+// the other On* methods taking a handler.
+func (b *Base) OnClick(handler func(ev core.Event)) Widget {
+	return b.setHandler("click", handler)
+}
+
+func (b *Base) OnDoubleClick(handler func(ev core.Event)) Widget {
+	return b.setHandler("dblclick", handler)
+}
+
+// OnMouseDown sets the handler called when a mouse button is pressed
+// on the widget. Event.Button tells which button is pressed.
+func (b *Base) OnMouseDown(handler func(ev core.Event)) Widget {
+	return b.setHandler("mousedown", handler)
+}
+
+func (b *Base) OnMouseUp(handler func(ev core.Event)) Widget {
+	return b.setHandler("mouseup", handler)
+}
+
+// OnKeyDown sets the handler called when a key is pressed while the widget
+// has the focus. Event.Key, Event.Code and Event.Modifiers tell which keys are pressed.
+func (b *Base) OnKeyDown(handler func(ev core.Event)) Widget {
+	return b.setHandler("keydown", handler)
+}
+
+func (b *Base) OnKeyUp(handler func(ev core.Event)) Widget {
+	return b.setHandler("keyup", handler)
+}
+
+func (b *Base) OnFocus(handler func(ev core.Event)) Widget {
+	return b.setHandler("focus", handler)
+}
+
+func (b *Base) OnBlur(handler func(ev core.Event)) Widget {
+	return b.setHandler("blur", handler)
+}
+
+// OnInput sets the handler called on every change of the value of
+// an input while it is edited. Event.StringProp("value") returns the value.
+func (b *Base) OnInput(handler func(ev core.Event)) Widget {
+	return b.setHandler("sl-input", handler)
+}
+
 func (b *Base) OnChange(handler func(ev core.Event)) Widget {
-	core.AddEventHandler(b.widget, "sl-change", handler)
-	return b.widget
+	return b.setHandler("sl-change", handler)
 }
 
+// EventPublisher is a model notifying its listeners of its changes.
+// Adding a listener returns a function removing it.
 type EventPublisher interface {
-	AddListener(w Widget)
+	AddListener(w Widget) (cancel func())
+	AddListenerFunc(f func()) (cancel func())
 	Notify()
 }
 
 type Model struct {
-	bus EventBus.Bus
+	listeners *listeners
 }
 
 func NewModel() Model {
 	return Model{
-		bus: EventBus.New(),
+		listeners: &listeners{},
 	}
 }
 
-func (m *Model) AddListener(b Widget) {
-	m.bus.Subscribe("update", b.Update)
+// AddListener makes w re-render every time the model is notified.
+func (m *Model) AddListener(w Widget) func() {
+	return m.AddListenerFunc(w.Update)
+}
+
+// AddListenerFunc registers f to be called every time the model is notified.
+func (m *Model) AddListenerFunc(f func()) func() {
+	return m.listeners.add(f)
 }
 
 func (m *Model) Notify() {
-	m.bus.Publish("update")
+	m.listeners.notify()
+}
+
+type listener struct {
+	f func()
+}
+
+type listeners struct {
+	mu   sync.Mutex
+	list []*listener
+}
+
+func (ls *listeners) add(f func()) func() {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	l := &listener{f}
+	ls.list = append(ls.list, l)
+	return func() {
+		ls.mu.Lock()
+		defer ls.mu.Unlock()
+
+		for i, x := range ls.list {
+			if x == l {
+				ls.list = append(ls.list[:i:i], ls.list[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// notify calls the listeners registered when it is called.
+// Listeners may add or remove listeners.
+func (ls *listeners) notify() {
+	ls.mu.Lock()
+	list := ls.list
+	ls.mu.Unlock()
+
+	for _, l := range list {
+		l.f()
+	}
 }
 
 func (b *Base) SizePolicy() SizePolicy {
@@ -440,10 +695,23 @@ func init() {
     onload="document.documentElement.classList.add('sl-theme-dark');">
   <script type="module" src="assets/node_modules/@shoelace-style/shoelace/dist/shoelace.js"></script>
 
+  <script src="assets/oden.js"></script>
+
   <link rel="stylesheet" href="assets/style.css">`)
 	core.SetTargetEvents([]core.TargetEvent{
-		{Name: "click", PropName: ""},
-		{Name: "sl-change", PropName: "value"}},
-	)
+		{Name: "click"},
+		{Name: "dblclick"},
+		{Name: "mousedown"},
+		{Name: "mouseup"},
+		{Name: "keydown"},
+		{Name: "keyup"},
+		{Name: "focus"},
+		{Name: "blur"},
+		{Name: "sl-change", PropNames: []string{"value", "checked"}},
+		{Name: "sl-input", PropNames: []string{"value"}},
+		{Name: "sl-request-close", PreventDefault: true},
+		{Name: "sl-tab-show", DetailNames: []string{"name"}},
+		{Name: "sl-close"},
+	})
 	core.MountAssets(assets)
 }
@@ -0,0 +1,84 @@
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	core "github.com/i2y/oden/core"
+	"github.com/i2y/oden/core/odentest"
+)
+
+func TestKeyedWidgetID(t *testing.T) {
+	var clicks int
+	b := Button("OK").Key("ok")
+	b.OnClick(func(ev core.Event) {
+		clicks++
+	})
+	d := odentest.New(t, Column(Text(State("a")), b))
+
+	if got := d.Find(`sl-button`); got != b.ID() || b.ID() != d.App().IDs().Key("ok") {
+		t.Errorf("button ID = %v, want the ID of its key", got)
+	}
+	d.Click(b.ID())
+	if clicks != 1 {
+		t.Errorf("clicks = %d, want 1", clicks)
+	}
+}
+
+func TestKeyAfterAttachPanics(t *testing.T) {
+	b := Button("OK")
+	odentest.New(t, b)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Key of an attached widget didn't panic")
+		}
+	}()
+	b.Key("ok")
+}
+
+// listenedState is a state counting the widgets listening to it.
+type listenedState struct {
+	*StateModel[int]
+	listeners int
+}
+
+func (s *listenedState) AddListener(w Widget) func() {
+	s.listeners++
+	cancel := s.StateModel.AddListener(w)
+	return func() {
+		s.listeners--
+		cancel()
+	}
+}
+
+func TestClosedWindowStopsListening(t *testing.T) {
+	d := odentest.New(t, Text(State("main")))
+	count := &listenedState{StateModel: State(0)}
+	var win *core.Window
+	d.Do(func() {
+		win = d.App().OpenWindow("Second", 400, 300, Column(Text(count), Button("+")))
+	})
+	conn := win.Connect()
+	defer conn.Close()
+	if count.listeners != 1 {
+		t.Fatalf("%d listeners of the model shown, want 1", count.listeners)
+	}
+	d.Updates()
+	for _, ok := conn.Receive(); ok; _, ok = conn.Receive() {
+	}
+
+	win.Close()
+	d.Do(func() {
+		count.Set(1)
+	})
+	if count.listeners != 0 {
+		t.Errorf("%d listeners of the model after its window closed, want 0", count.listeners)
+	}
+	if updates := d.Updates(); len(updates) != 0 {
+		t.Errorf("updates %v sent after the window showing the model closed", updates)
+	}
+	if msg, ok := conn.Receive(); ok && !strings.Contains(msg, `"close"`) {
+		t.Errorf("message %q sent to the closed window", msg)
+	}
+}
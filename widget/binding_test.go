@@ -0,0 +1,97 @@
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/i2y/oden/core/odentest"
+)
+
+// assertPageInSync fails the test if the page updated by the messages sent
+// by the App differs from the current view.
+func assertPageInSync(t *testing.T, d *odentest.Driver) {
+	t.Helper()
+
+	page, err := odentest.Normalize(d.Page())
+	if err != nil {
+		t.Fatal(err)
+	}
+	view, err := odentest.Normalize(d.HTML())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page != view {
+		t.Errorf("page out of sync with the view\n--- page\n%s--- view\n%s", page, view)
+	}
+}
+
+func TestInputBinding(t *testing.T) {
+	count := State(1)
+	input := InputWithState(NumberInputType, "Count", count)
+	label := Text(count)
+	d := odentest.New(t, Column(input, label))
+
+	d.Input(input.ID(), "42")
+	if count.Get() != 42 {
+		t.Errorf("count = %d, want 42", count.Get())
+	}
+	// The input already shows the value, so only the text is updated.
+	updates := d.Updates()
+	if len(updates) != 1 || updates[0].Target != label.ID().String() {
+		t.Errorf("updates = %+v, want only %s", updates, label.ID())
+	}
+	assertPageInSync(t, d)
+
+	d.Input(input.ID(), "4x")
+	if count.Get() != 42 {
+		t.Errorf("count = %d after an invalid value, want 42", count.Get())
+	}
+
+	d.Do(func() {
+		count.Set(7)
+	})
+	if !strings.Contains(d.Page(), `value="7"`) {
+		t.Errorf("input not updated from the state: %s", d.Page())
+	}
+	assertPageInSync(t, d)
+}
+
+func TestTextAreaBinding(t *testing.T) {
+	text := State("")
+	area := TextAreaWithState("Notes", text)
+	d := odentest.New(t, area)
+
+	d.Input(area.ID(), "first line")
+	d.Change(area.ID(), map[string]interface{}{"value": "first line\nsecond"})
+	if text.Get() != "first line\nsecond" {
+		t.Errorf("text = %q, want the changed value", text.Get())
+	}
+
+	d.Do(func() {
+		text.Set("reset")
+	})
+	if area.Value() != "reset" || !strings.Contains(d.Page(), `value="reset"`) {
+		t.Errorf("text area not updated from the state: %s", d.Page())
+	}
+	assertPageInSync(t, d)
+}
+
+func TestSwitchBinding(t *testing.T) {
+	on := State(false)
+	sw := SwitchWithModel(on, "Dark mode")
+	d := odentest.New(t, sw)
+
+	d.Change(sw.ID(), map[string]interface{}{"checked": true})
+	if !on.Get() {
+		t.Error("state not checked by the switch")
+	}
+	assertPageInSync(t, d)
+
+	d.Do(func() {
+		on.Set(false)
+	})
+	if strings.Contains(d.Page(), "checked") {
+		t.Errorf("switch not unchecked from the state: %s", d.Page())
+	}
+	assertPageInSync(t, d)
+}
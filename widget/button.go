@@ -15,6 +15,11 @@ func Button(label string, options ...func(*ButtonOption)) *ButtonWidget {
 	return ButtonWithModel(NewButtonModel(label), options...)
 }
 
+// ButtonWithState returns a button whose label follows the given state.
+func ButtonWithState(label StringEventPublisher, options ...func(*ButtonOption)) *ButtonWidget {
+	return ButtonWithModel(NewButtonModelWithState(label), options...)
+}
+
 func ButtonWithModel(m *ButtonModel, options ...func(*ButtonOption)) *ButtonWidget {
 	o := &ButtonOption{
 		kind:  DefaultKind,
@@ -29,7 +34,7 @@ func ButtonWithModel(m *ButtonModel, options ...func(*ButtonOption)) *ButtonWidg
 		model:  m,
 		option: o,
 	}
-	m.AddListener(b)
+	b.listen(m, m.label)
 	b.Base.SetWidget(b)
 	return b
 }
@@ -42,7 +47,7 @@ func (b *ButtonWidget) View() string {
 		b.option,
 		b.SizeStyle(),
 		b.OtherStyle(),
-		html.EscapeString(b.model.label),
+		html.EscapeString(b.model.Label()),
 		b.ID(),
 		"--sl-input-height-medium: 100%",
 		b.TextStyle(),
@@ -50,7 +55,7 @@ func (b *ButtonWidget) View() string {
 }
 
 func (b *ButtonWidget) Label() string {
-	return b.model.label
+	return b.model.Label()
 }
 
 func (b *ButtonWidget) SetLabel(label string) *ButtonWidget {
@@ -70,12 +75,16 @@ func (b *ButtonWidget) Enable() *ButtonWidget {
 
 type ButtonModel struct {
 	Model
-	label    string
+	label    StringEventPublisher
 	disabled bool
 	loading  bool
 }
 
 func NewButtonModel(label string) *ButtonModel {
+	return NewButtonModelWithState(State(label))
+}
+
+func NewButtonModelWithState(label StringEventPublisher) *ButtonModel {
 	return &ButtonModel{
 		Model:    NewModel(),
 		label:    label,
@@ -85,12 +94,13 @@ func NewButtonModel(label string) *ButtonModel {
 }
 
 func (bm *ButtonModel) Label() string {
-	return bm.label
+	return bm.label.String()
 }
 
+// SetLabel sets the label through the label state, so that every widget
+// bound to the state is updated as well.
 func (bm *ButtonModel) SetLabel(label string) {
-	bm.label = label
-	bm.Notify()
+	bm.label.SetString(label)
 }
 
 func (bm *ButtonModel) Disable() {
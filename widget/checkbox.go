@@ -0,0 +1,92 @@
+package widget
+
+import (
+	"fmt"
+	"html"
+
+	core "github.com/i2y/oden/core"
+)
+
+type CheckboxWidget struct {
+	Base
+	model    ValuePublisher[bool]
+	label    string
+	disabled bool
+	onToggle func(checked bool)
+}
+
+func Checkbox(checked bool, label string) *CheckboxWidget {
+	return CheckboxWithModel(State(checked), label)
+}
+
+func CheckboxWithModel(b ValuePublisher[bool], label string) *CheckboxWidget {
+	c := &CheckboxWidget{
+		Base:  NewBase(),
+		model: b,
+		label: label,
+	}
+	c.listen(b)
+	c.Base.SetWidget(c)
+	c.On("sl-change", c.receiveChecked)
+	return c
+}
+
+func (c *CheckboxWidget) View() string {
+	return fmt.Sprintf(
+		`<sl-checkbox id="%s" style="%s %s" %s %s>%s</sl-checkbox>
+		 <style>sl-checkbox#%s::part(base) {%s}</style>`,
+		c.ID(),
+		c.SizeStyle(),
+		c.OtherStyle(),
+		checkedAttr(c.model.Get()),
+		disabledAttr(c.disabled),
+		html.EscapeString(c.label),
+
+		c.ID(),
+		c.TextStyle(),
+	)
+}
+
+func (c *CheckboxWidget) Checked() bool {
+	return c.model.Get()
+}
+
+func (c *CheckboxWidget) SetChecked(checked bool) *CheckboxWidget {
+	c.model.Set(checked)
+	return c
+}
+
+func (c *CheckboxWidget) Disable() *CheckboxWidget {
+	c.disabled = true
+	c.Update()
+	return c
+}
+
+func (c *CheckboxWidget) Enable() *CheckboxWidget {
+	c.disabled = false
+	c.Update()
+	return c
+}
+
+// OnToggle sets the function called with the new state
+// when the user checks or unchecks the checkbox.
+func (c *CheckboxWidget) OnToggle(handler func(checked bool)) *CheckboxWidget {
+	c.onToggle = handler
+	return c
+}
+
+func (c *CheckboxWidget) receiveChecked(ev core.Event) {
+	checked, ok := ev.BoolProp("checked")
+	if !ok {
+		return
+	}
+	if checked == c.model.Get() {
+		return
+	}
+	c.receive(func() {
+		c.model.Set(checked)
+	})
+	if c.onToggle != nil {
+		c.onToggle(checked)
+	}
+}
@@ -0,0 +1,26 @@
+package widget
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i2y/oden/core/odentest"
+)
+
+func TestCheckboxToggle(t *testing.T) {
+	c := Checkbox(false, "I agree")
+	var toggled []bool
+	c.OnToggle(func(checked bool) {
+		toggled = append(toggled, checked)
+	})
+	d := odentest.New(t, c)
+
+	d.Change(c.ID(), map[string]interface{}{"checked": true})
+	// The page may report the state the checkbox already has.
+	d.Change(c.ID(), map[string]interface{}{"checked": true})
+	d.Change(c.ID(), map[string]interface{}{"checked": false})
+	if !reflect.DeepEqual(toggled, []bool{true, false}) || c.Checked() {
+		t.Errorf("toggled %v, checked %v, want [true false] and unchecked", toggled, c.Checked())
+	}
+	assertPageInSync(t, d)
+}
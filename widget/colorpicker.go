@@ -0,0 +1,145 @@
+package widget
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+
+	core "github.com/i2y/oden/core"
+)
+
+type ColorPickerWidget struct {
+	Base
+	model    ValuePublisher[string]
+	format   ColorFormat
+	opacity  bool
+	swatches []string
+	disabled bool
+}
+
+// ColorPicker returns a button opening a color picker, initially showing value,
+// a CSS color such as "#4a90e2" or "rgb(74, 144, 226)".
+func ColorPicker(value string) *ColorPickerWidget {
+	return ColorPickerWithModel(State(value))
+}
+
+// ColorPickerWithModel returns a color picker bound to v. Colors picked
+// by the user are stored in v in the format of the picker.
+func ColorPickerWithModel(v ValuePublisher[string]) *ColorPickerWidget {
+	c := &ColorPickerWidget{
+		Base:   NewBase(),
+		model:  v,
+		format: HexColorFormat,
+	}
+	c.listen(v)
+	c.Base.SetWidget(c)
+	c.On("sl-change", c.receiveValue)
+	return c
+}
+
+func (c *ColorPickerWidget) View() string {
+	return fmt.Sprintf(
+		`<sl-color-picker id="%s" style="%s %s" value="%s" format="%s" %s %s %s size="medium" hoist></sl-color-picker>`,
+		c.ID(),
+		c.SizeStyle(),
+		c.OtherStyle(),
+		html.EscapeString(c.model.Get()),
+		c.format,
+		opacityAttr(c.opacity),
+		c.swatchesAttr(),
+		disabledAttr(c.disabled),
+	)
+}
+
+// swatchesAttr returns the attribute holding the swatches; see assets/oden.js.
+func (c *ColorPickerWidget) swatchesAttr() string {
+	if c.swatches == nil {
+		return ""
+	}
+	b, err := json.Marshal(c.swatches)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf(`data-swatches="%s"`, html.EscapeString(string(b)))
+}
+
+func (c *ColorPickerWidget) Value() string {
+	return c.model.Get()
+}
+
+func (c *ColorPickerWidget) SetValue(value string) *ColorPickerWidget {
+	c.model.Set(value)
+	return c
+}
+
+// Format sets the format of the colors picked by the user.
+func (c *ColorPickerWidget) Format(format ColorFormat) *ColorPickerWidget {
+	c.format = format
+	c.Update()
+	return c
+}
+
+// Opacity lets the user pick the opacity too, in which case the colors are
+// in the format with an alpha channel, e.g. "#4a90e2ff" or "rgba(74, 144, 226, 1)".
+func (c *ColorPickerWidget) Opacity() *ColorPickerWidget {
+	c.opacity = true
+	c.Update()
+	return c
+}
+
+// Swatches sets the colors offered below the picker instead of the default ones.
+// No swatches are shown if colors is empty.
+func (c *ColorPickerWidget) Swatches(colors ...string) *ColorPickerWidget {
+	c.swatches = append([]string{}, colors...)
+	c.Update()
+	return c
+}
+
+func (c *ColorPickerWidget) Disable() *ColorPickerWidget {
+	c.disabled = true
+	c.Update()
+	return c
+}
+
+func (c *ColorPickerWidget) Enable() *ColorPickerWidget {
+	c.disabled = false
+	c.Update()
+	return c
+}
+
+func (c *ColorPickerWidget) receiveValue(ev core.Event) {
+	value, ok := ev.StringProp("value")
+	if !ok {
+		return
+	}
+	c.receive(func() {
+		c.model.Set(value)
+	})
+}
+
+func opacityAttr(opacity bool) string {
+	if opacity {
+		return "opacity"
+	}
+	return ""
+}
+
+type ColorFormat int
+
+const (
+	HexColorFormat ColorFormat = iota
+	RGBColorFormat
+	HSLColorFormat
+)
+
+func (f ColorFormat) String() string {
+	switch f {
+	case HexColorFormat:
+		return "hex"
+	case RGBColorFormat:
+		return "rgb"
+	case HSLColorFormat:
+		return "hsl"
+	}
+	return "hex"
+}
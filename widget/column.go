@@ -19,7 +19,7 @@ func Column(children ...Widget) *ColumnLayout {
 func (c *ColumnLayout) View() string {
 	c.layout()
 	return fmt.Sprintf(
-		`<div id="oden-%s" style="%s %s">%s</div>`,
+		`<div id="%s" style="%s %s">%s</div>`,
 		c.ID(),
 		c.style(),
 		c.SizeStyle(),
@@ -9,10 +9,12 @@ type Component struct {
 }
 
 func NewComponent(builder func() Widget) *Component {
-	return &Component{
-		Base: NewBase(),
+	c := &Component{
+		Base:    NewBase(),
 		builder: builder,
 	}
+	c.Base.SetWidget(c)
+	return c
 }
 
 func (c *Component) Attach(a *core.App) {
@@ -22,6 +24,7 @@ func (c *Component) Attach(a *core.App) {
 
 func (c *Component) Detach() {
 	c.Base.Detach()
+	c.build().Detach()
 }
 
 func (c *Component) View() string {
@@ -0,0 +1,65 @@
+package widget
+
+import (
+	"fmt"
+
+	core "github.com/i2y/oden/core"
+)
+
+type ContextMenuWidget struct {
+	Base
+	child Widget
+	menu  *MenuWidget
+}
+
+// ContextMenu returns w opening menu at the pointer when it is right-clicked.
+// The returned widget takes over the size of w, so it should be laid out
+// in place of w.
+func ContextMenu(w Widget, menu *MenuWidget) *ContextMenuWidget {
+	c := &ContextMenuWidget{
+		Base:  NewBase(),
+		child: w,
+		menu:  menu,
+	}
+	c.sizePolicy = w.SizePolicy()
+	c.width = w.Width()
+	c.height = w.Height()
+	w.SetSizeStyle("width: 100%; height: 100%;")
+	c.Base.SetWidget(c)
+	return c
+}
+
+func (c *ContextMenuWidget) Attach(a *core.App) {
+	c.Base.Attach(a)
+
+	c.child.Attach(a)
+	c.menu.Attach(a)
+}
+
+func (c *ContextMenuWidget) Detach() {
+	c.Base.Detach()
+
+	c.child.Detach()
+	c.menu.Detach()
+}
+
+// The menu is in a dropdown moved to the pointer and opened by
+// assets/oden.js.
+func (c *ContextMenuWidget) View() string {
+	return fmt.Sprintf(
+		`<div id="%s" style="%s %s" data-context-menu>%s<sl-dropdown class="oden-context-menu" style="position: fixed;" hoist><span slot="trigger"></span>%s</sl-dropdown></div>`,
+		c.ID(),
+		c.SizeStyle(),
+		c.OtherStyle(),
+		c.child.View(),
+		c.menu.View(),
+	)
+}
+
+func (c *ContextMenuWidget) Child() Widget {
+	return c.child
+}
+
+func (c *ContextMenuWidget) Menu() *MenuWidget {
+	return c.menu
+}
@@ -84,14 +84,14 @@ func DataTable(m *TableModel) *DataTableWidget {
 		style: &DataTableStyle{},
 		tmpl:  tmpl,
 	}
-	m.AddListener(dt)
+	dt.listen(m)
 	dt.Base.SetWidget(dt)
 	return dt
 }
 
 func (dt *DataTableWidget) View() string {
 	return fmt.Sprintf(
-		`<div id="%d" style="%s %s width: auto; height: auto;"><table style="%s %s width: 100%%; height: 100%%;">%s</table></div>`,
+		`<div id="%s" style="%s %s width: auto; height: auto;"><table style="%s %s width: 100%%; height: 100%%;">%s</table></div>`,
 		dt.ID(),
 		dt.OtherStyle(),
 		dt.SizeStyle(),
@@ -0,0 +1,139 @@
+package widget
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	core "github.com/i2y/oden/core"
+)
+
+// modal is the part shared by dialogs and drawers: a title, a body and
+// footer widgets shown on top of the page while the open state is true.
+type modal struct {
+	Base
+	open           ValuePublisher[bool]
+	title          string
+	body           Widget
+	footer         []Widget
+	onRequestClose func() bool
+}
+
+func newModal(open ValuePublisher[bool], title string, body Widget, footer []Widget) modal {
+	return modal{
+		Base:   NewBase(),
+		open:   open,
+		title:  title,
+		body:   body,
+		footer: footer,
+	}
+}
+
+func (m *modal) Attach(a *core.App) {
+	m.Base.Attach(a)
+
+	m.body.Attach(a)
+	for _, w := range m.footer {
+		w.Attach(a)
+	}
+}
+
+func (m *modal) Detach() {
+	m.Base.Detach()
+
+	m.body.Detach()
+	for _, w := range m.footer {
+		w.Detach()
+	}
+}
+
+// Show opens the dialog or drawer.
+func (m *modal) Show() {
+	m.open.Set(true)
+}
+
+// Hide closes the dialog or drawer.
+func (m *modal) Hide() {
+	m.open.Set(false)
+}
+
+func (m *modal) IsOpen() bool {
+	return m.open.Get()
+}
+
+// OnRequestClose sets the function called when the user tries to close
+// the dialog or drawer, by its close button, the Escape key or a click
+// on the overlay. It is closed unless handler returns false.
+func (m *modal) OnRequestClose(handler func() bool) Widget {
+	m.onRequestClose = handler
+	return m.widget
+}
+
+func (m *modal) requestClose(core.Event) {
+	if m.onRequestClose != nil && !m.onRequestClose() {
+		return
+	}
+	m.Hide()
+}
+
+// content returns the HTML of the body and the footer.
+func (m *modal) content() string {
+	var footer strings.Builder
+	for _, w := range m.footer {
+		footer.WriteString(w.View())
+	}
+	view := m.body.View()
+	if footer.Len() > 0 {
+		view += fmt.Sprintf(
+			`<div slot="footer" style="display: flex; justify-content: flex-end; gap: var(--sl-spacing-small);">%s</div>`,
+			footer.String(),
+		)
+	}
+	return view
+}
+
+func openAttr(open bool) string {
+	if open {
+		return "open"
+	}
+	return ""
+}
+
+type DialogWidget struct {
+	modal
+}
+
+// Dialog returns a modal dialog showing body, with the footer widgets,
+// typically buttons, at its bottom. The dialog is hidden until Show is called.
+func Dialog(title string, body Widget, footer ...Widget) *DialogWidget {
+	return DialogWithModel(State(false), title, body, footer...)
+}
+
+// DialogWithModel returns a dialog shown while open is true.
+func DialogWithModel(open ValuePublisher[bool], title string, body Widget, footer ...Widget) *DialogWidget {
+	d := &DialogWidget{
+		modal: newModal(open, title, body, footer),
+	}
+	d.listen(open)
+	d.Base.SetWidget(d)
+	d.On("sl-request-close", d.requestClose)
+	return d
+}
+
+func (d *DialogWidget) View() string {
+	return fmt.Sprintf(
+		`<sl-dialog id="%s" label="%s" style="%s" %s>%s</sl-dialog>`,
+		d.ID(),
+		html.EscapeString(d.title),
+		d.widthStyle(),
+		openAttr(d.open.Get()),
+		d.content(),
+	)
+}
+
+func (d *DialogWidget) widthStyle() string {
+	if d.Width() <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("--width: %dpx;", d.Width())
+}
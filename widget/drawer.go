@@ -0,0 +1,58 @@
+package widget
+
+import (
+	"fmt"
+	"html"
+)
+
+type DrawerWidget struct {
+	modal
+	placement Placement
+}
+
+// Drawer returns a drawer sliding in from the given edge of the window,
+// showing body with the footer widgets at its bottom.
+// The drawer is hidden until Show is called.
+func Drawer(placement Placement, title string, body Widget, footer ...Widget) *DrawerWidget {
+	return DrawerWithModel(State(false), placement, title, body, footer...)
+}
+
+// DrawerWithModel returns a drawer shown while open is true.
+func DrawerWithModel(open ValuePublisher[bool], placement Placement, title string, body Widget, footer ...Widget) *DrawerWidget {
+	d := &DrawerWidget{
+		modal:     newModal(open, title, body, footer),
+		placement: placement,
+	}
+	d.listen(open)
+	d.Base.SetWidget(d)
+	d.On("sl-request-close", d.requestClose)
+	return d
+}
+
+func (d *DrawerWidget) View() string {
+	return fmt.Sprintf(
+		`<sl-drawer id="%s" label="%s" placement="%s" style="%s" %s>%s</sl-drawer>`,
+		d.ID(),
+		html.EscapeString(d.title),
+		d.placement,
+		d.sizeStyle(),
+		openAttr(d.open.Get()),
+		d.content(),
+	)
+}
+
+// sizeStyle returns the style setting the width of a drawer at the start
+// or the end, or the height of one at the top or the bottom.
+func (d *DrawerWidget) sizeStyle() string {
+	switch d.placement {
+	case TopPlacement, BottomPlacement:
+		if d.Height() > 0 {
+			return fmt.Sprintf("--size: %dpx;", d.Height())
+		}
+	default:
+		if d.Width() > 0 {
+			return fmt.Sprintf("--size: %dpx;", d.Width())
+		}
+	}
+	return ""
+}
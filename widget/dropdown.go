@@ -0,0 +1,111 @@
+package widget
+
+import (
+	"fmt"
+
+	core "github.com/i2y/oden/core"
+)
+
+type DropdownWidget struct {
+	Base
+	trigger   Widget
+	menu      *MenuWidget
+	placement Placement
+	stayOpen  bool
+	disabled  bool
+}
+
+// Dropdown returns trigger, typically a button, which opens menu below it
+// when it is clicked. The menu is closed when one of its items is selected.
+func Dropdown(trigger Widget, menu *MenuWidget) *DropdownWidget {
+	d := &DropdownWidget{
+		Base:      NewBase(),
+		trigger:   trigger,
+		menu:      menu,
+		placement: BottomPlacement,
+	}
+	trigger.SetSizeStyle("width: 100%; height: 100%;")
+	d.Base.SetWidget(d)
+	return d
+}
+
+func (d *DropdownWidget) Attach(a *core.App) {
+	d.Base.Attach(a)
+
+	d.trigger.Attach(a)
+	d.menu.Attach(a)
+}
+
+func (d *DropdownWidget) Detach() {
+	d.Base.Detach()
+
+	d.trigger.Detach()
+	d.menu.Detach()
+}
+
+func (d *DropdownWidget) View() string {
+	return fmt.Sprintf(
+		`<sl-dropdown id="%s" style="%s %s" placement="%s" hoist %s %s><div slot="trigger" style="width: 100%%; height: 100%%;">%s</div>%s</sl-dropdown>`,
+		d.ID(),
+		d.SizeStyle(),
+		d.OtherStyle(),
+		dropdownPlacement(d.placement),
+		stayOpenAttr(d.stayOpen),
+		disabledAttr(d.disabled),
+		d.trigger.View(),
+		d.menu.View(),
+	)
+}
+
+func (d *DropdownWidget) Menu() *MenuWidget {
+	return d.menu
+}
+
+// Placement sets the side of the trigger where the menu is opened,
+// BottomPlacement by default.
+func (d *DropdownWidget) Placement(placement Placement) *DropdownWidget {
+	d.placement = placement
+	d.Update()
+	return d
+}
+
+// StayOpenOnSelect keeps the menu open when an item is selected,
+// which is handy for menus of checkable items.
+func (d *DropdownWidget) StayOpenOnSelect() *DropdownWidget {
+	d.stayOpen = true
+	d.Update()
+	return d
+}
+
+func (d *DropdownWidget) Disable() *DropdownWidget {
+	d.disabled = true
+	d.Update()
+	return d
+}
+
+func (d *DropdownWidget) Enable() *DropdownWidget {
+	d.disabled = false
+	d.Update()
+	return d
+}
+
+// dropdownPlacement returns the placement of a dropdown panel aligned with
+// the start of its trigger.
+func dropdownPlacement(p Placement) string {
+	switch p {
+	case TopPlacement:
+		return "top-start"
+	case EndPlacement:
+		return "right-start"
+	case StartPlacement:
+		return "left-start"
+	}
+	return "bottom-start"
+}
+
+func stayOpenAttr(stayOpen bool) string {
+	if stayOpen {
+		return "stay-open-on-select"
+	}
+	return ""
+}
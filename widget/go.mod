@@ -1,11 +1,8 @@
 module github.com/i2y/oden/widget
 
-go 1.17
+go 1.18
 
-require (
-	github.com/asaskevich/EventBus v0.0.0-20200907212545-49d423059eef
-	github.com/i2y/oden/core v0.0.0-20211212235841-8edff076c223
-)
+require github.com/i2y/oden/core v0.1.0
 
 require (
 	github.com/jchv/go-webview2 v0.0.0-20211201160117-0a1f544bbf5a // indirect
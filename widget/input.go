@@ -2,6 +2,9 @@ package widget
 
 import (
 	"fmt"
+	"html"
+
+	core "github.com/i2y/oden/core"
 )
 
 type InputWidget struct {
@@ -13,27 +16,37 @@ func Input(inputType InputType, placeholder string) *InputWidget {
 	return InputWithModel(NewInputModel(inputType, placeholder))
 }
 
+// InputWithState returns an input bound to the given state.
+// Text entered in the browser is stored in the state through SetString,
+// and changes of the state are reflected in the input.
+func InputWithState(inputType InputType, placeholder string, value StringEventPublisher) *InputWidget {
+	return InputWithModel(NewInputModelWithState(inputType, placeholder, value))
+}
+
 func InputWithModel(m *InputModel) *InputWidget {
 	i := &InputWidget{
 		Base:  NewBase(),
 		model: m,
 	}
-	m.AddListener(i)
+	i.listen(m, m.value)
 	i.Base.SetWidget(i)
+	i.On("sl-input", i.receiveValue)
+	i.On("sl-change", i.receiveValue)
 	return i
 }
 
 func (i *InputWidget) View() string {
 	return fmt.Sprintf(
 		`<div style="%s">
-		   <sl-input id="%s" style="%s" type="%s" placeholder="%s" size="medium" clearable></sl-button>
+		   <sl-input id="%s" style="%s" type="%s" placeholder="%s" value="%s" size="medium" clearable></sl-input>
 		 </div>
 		 <style>sl-input#%s::part(base) {%s; %s}</style>`,
 		i.SizeStyle(),
 		i.ID(),
 		i.OtherStyle(),
 		i.model.inputType,
-		i.model.placeholder,
+		html.EscapeString(i.model.placeholder),
+		html.EscapeString(i.model.Value()),
 
 		i.ID(),
 		"--sl-input-height-medium: 100%",
@@ -41,17 +54,42 @@ func (i *InputWidget) View() string {
 	)
 }
 
+func (i *InputWidget) Value() string {
+	return i.model.Value()
+}
+
+func (i *InputWidget) SetValue(value string) *InputWidget {
+	i.model.SetValue(value)
+	return i
+}
+
+func (i *InputWidget) receiveValue(ev core.Event) {
+	value, ok := ev.StringProp("value")
+	if !ok {
+		return
+	}
+	i.receive(func() {
+		i.model.value.SetString(value)
+	})
+}
+
 type InputModel struct {
 	Model
 	inputType   InputType
 	placeholder string
+	value       StringEventPublisher
 }
 
 func NewInputModel(t InputType, placeholder string) *InputModel {
+	return NewInputModelWithState(t, placeholder, State(""))
+}
+
+func NewInputModelWithState(t InputType, placeholder string, value StringEventPublisher) *InputModel {
 	return &InputModel{
 		Model:       NewModel(),
 		inputType:   t,
 		placeholder: placeholder,
+		value:       value,
 	}
 }
 
@@ -71,6 +109,14 @@ func (im *InputModel) SetPlaceholder(placeholder string) {
 	im.placeholder = placeholder
 }
 
+func (im *InputModel) Value() string {
+	return im.value.String()
+}
+
+func (im *InputModel) SetValue(value string) {
+	im.value.SetString(value)
+}
+
 type InputType int
 
 const (
@@ -36,9 +36,13 @@ func (l *Layout) Children() []Widget {
 
 func (l *Layout) Add(w Widget) {
 	l.children = append(l.children, w)
+	if l.attached {
+		w.Attach(l.app)
+	}
 	l.Update()
 }
 
+// Remove removes w from the layout and detaches it.
 func (l *Layout) Remove(w Widget) {
 	new := make([]Widget, len(l.children))
 	i := 0
@@ -49,6 +53,9 @@ func (l *Layout) Remove(w Widget) {
 		}
 	}
 	l.children = new[:i]
+	if l.attached {
+		w.Detach()
+	}
 	l.Update()
 }
 
@@ -0,0 +1,254 @@
+package widget
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	core "github.com/i2y/oden/core"
+)
+
+type MenuWidget struct {
+	Base
+	items    []*MenuItemWidget
+	parent   *MenuItemWidget
+	onSelect func(item *MenuItemWidget)
+}
+
+// Menu returns a list of items, navigable with the arrow keys and by typing
+// the label of an item, which is usually shown by a dropdown or a context menu.
+func Menu(items ...*MenuItemWidget) *MenuWidget {
+	m := &MenuWidget{
+		Base: NewBase(),
+	}
+	for _, item := range items {
+		item.menu = m
+		m.items = append(m.items, item)
+	}
+	m.Base.SetWidget(m)
+	return m
+}
+
+func (m *MenuWidget) Attach(a *core.App) {
+	m.Base.Attach(a)
+
+	for _, item := range m.items {
+		item.Attach(a)
+	}
+}
+
+func (m *MenuWidget) Detach() {
+	m.Base.Detach()
+
+	for _, item := range m.items {
+		item.Detach()
+	}
+}
+
+func (m *MenuWidget) View() string {
+	var items strings.Builder
+	for _, item := range m.items {
+		items.WriteString(item.View())
+	}
+	return fmt.Sprintf(
+		`<sl-menu id="%s" style="%s %s">%s</sl-menu>`,
+		m.ID(),
+		m.SizeStyle(),
+		m.OtherStyle(),
+		items.String(),
+	)
+}
+
+func (m *MenuWidget) Items() []*MenuItemWidget {
+	return m.items
+}
+
+// Add adds item after the other items.
+func (m *MenuWidget) Add(item *MenuItemWidget) {
+	item.menu = m
+	m.items = append(m.items, item)
+	if m.attached {
+		item.Attach(m.app)
+	}
+	m.Update()
+}
+
+func (m *MenuWidget) Remove(item *MenuItemWidget) {
+	for i, c := range m.items {
+		if c == item {
+			m.items = append(m.items[:i:i], m.items[i+1:]...)
+			if m.attached {
+				item.Detach()
+			}
+			item.menu = nil
+			m.Update()
+			return
+		}
+	}
+}
+
+// OnSelect sets the function called with the item the user selects in the
+// menu or in one of its submenus.
+func (m *MenuWidget) OnSelect(handler func(item *MenuItemWidget)) *MenuWidget {
+	m.onSelect = handler
+	return m
+}
+
+// selected calls the selection handlers of the menu and of the menus above it.
+func (m *MenuWidget) selected(item *MenuItemWidget) {
+	for m != nil {
+		if m.onSelect != nil {
+			m.onSelect(item)
+		}
+		if m.parent == nil {
+			return
+		}
+		m = m.parent.menu
+	}
+}
+
+// MenuItemWidget is an item of a menu.
+type MenuItemWidget struct {
+	Base
+	label    string
+	checked  ValuePublisher[bool]
+	disabled bool
+	submenu  *MenuWidget
+	menu     *MenuWidget
+	onSelect func()
+}
+
+func MenuItem(label string) *MenuItemWidget {
+	item := &MenuItemWidget{
+		Base:  NewBase(),
+		label: label,
+	}
+	item.Base.SetWidget(item)
+	item.On("click", item.receiveSelect)
+	return item
+}
+
+// CheckMenuItem returns an item showing a check mark while it is checked,
+// which is toggled when the item is selected.
+func CheckMenuItem(label string, checked bool) *MenuItemWidget {
+	return CheckMenuItemWithModel(label, State(checked))
+}
+
+func CheckMenuItemWithModel(label string, checked ValuePublisher[bool]) *MenuItemWidget {
+	item := MenuItem(label)
+	item.checked = checked
+	item.listen(checked)
+	return item
+}
+
+// SubMenu returns an item opening a menu of items beside it when it is
+// selected or the right arrow key is pressed on it. The left arrow key and
+// the Escape key close the submenu.
+func SubMenu(label string, items ...*MenuItemWidget) *MenuItemWidget {
+	item := MenuItem(label)
+	item.submenu = Menu(items...)
+	item.submenu.parent = item
+	return item
+}
+
+func (item *MenuItemWidget) Attach(a *core.App) {
+	item.Base.Attach(a)
+
+	if item.submenu != nil {
+		item.submenu.Attach(a)
+	}
+}
+
+func (item *MenuItemWidget) Detach() {
+	item.Base.Detach()
+
+	if item.submenu != nil {
+		item.submenu.Detach()
+	}
+}
+
+// A submenu is a dropdown in the suffix of its item, opened by
+// assets/oden.js. Key presses in the submenu are kept from the menu of the
+// item, which would move its own focus otherwise.
+func (item *MenuItemWidget) View() string {
+	var submenu string
+	if item.submenu != nil {
+		submenu = fmt.Sprintf(
+			`<sl-icon slot="suffix" name="chevron-right"></sl-icon>
+			 <sl-dropdown class="oden-submenu" slot="suffix" placement="right-start" hoist onkeydown="odenSubmenuKeyDown(event)"><span slot="trigger"></span>%s</sl-dropdown>`,
+			item.submenu.View(),
+		)
+	}
+	return fmt.Sprintf(
+		`<sl-menu-item id="%s" %s %s>%s%s</sl-menu-item>`,
+		item.ID(),
+		checkedAttr(item.Checked()),
+		disabledAttr(item.disabled),
+		html.EscapeString(item.label),
+		submenu,
+	)
+}
+
+func (item *MenuItemWidget) Label() string {
+	return item.label
+}
+
+func (item *MenuItemWidget) SetLabel(label string) *MenuItemWidget {
+	item.label = label
+	item.Update()
+	return item
+}
+
+// Checked returns whether the item is checked, which is always false
+// unless the item is made by CheckMenuItem or CheckMenuItemWithModel.
+func (item *MenuItemWidget) Checked() bool {
+	return item.checked != nil && item.checked.Get()
+}
+
+func (item *MenuItemWidget) SetChecked(checked bool) *MenuItemWidget {
+	if item.checked != nil {
+		item.checked.Set(checked)
+	}
+	return item
+}
+
+// SubMenu returns the menu opened by the item, or nil.
+func (item *MenuItemWidget) SubMenu() *MenuWidget {
+	return item.submenu
+}
+
+func (item *MenuItemWidget) Disable() *MenuItemWidget {
+	item.disabled = true
+	item.Update()
+	return item
+}
+
+func (item *MenuItemWidget) Enable() *MenuItemWidget {
+	item.disabled = false
+	item.Update()
+	return item
+}
+
+// OnSelect sets the function called when the user selects the item,
+// by clicking it or pressing Enter on it.
+func (item *MenuItemWidget) OnSelect(handler func()) *MenuItemWidget {
+	item.onSelect = handler
+	return item
+}
+
+// receiveSelect handles a click on the item, which the menu also makes when
+// Enter is pressed. An item with a submenu opens it instead of being selected.
+func (item *MenuItemWidget) receiveSelect(core.Event) {
+	if item.disabled || item.submenu != nil {
+		return
+	}
+	if item.checked != nil {
+		item.checked.Set(!item.checked.Get())
+	}
+	if item.onSelect != nil {
+		item.onSelect()
+	}
+	if item.menu != nil {
+		item.menu.selected(item)
+	}
+}
@@ -0,0 +1,131 @@
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/i2y/oden/core/odentest"
+)
+
+func TestMenuItemSelect(t *testing.T) {
+	opened := 0
+	open := MenuItem("Open").OnSelect(func() {
+		opened++
+	})
+	var selected []*MenuItemWidget
+	menu := Menu(open, MenuItem("Close")).OnSelect(func(item *MenuItemWidget) {
+		selected = append(selected, item)
+	})
+	d := odentest.New(t, menu)
+
+	d.Click(open.ID())
+	if opened != 1 || len(selected) != 1 || selected[0] != open {
+		t.Errorf("item selected %d times, menu got %v, want the item once", opened, selected)
+	}
+
+	d.Do(func() {
+		open.Disable()
+	})
+	d.Click(open.ID())
+	if opened != 1 || len(selected) != 1 {
+		t.Errorf("disabled item selected %d times, menu got %v", opened, selected)
+	}
+}
+
+func TestCheckMenuItemToggles(t *testing.T) {
+	bold := CheckMenuItem("Bold", false)
+	d := odentest.New(t, Menu(bold))
+
+	d.Click(bold.ID())
+	if !bold.Checked() || !strings.Contains(d.Page(), "checked") {
+		t.Errorf("item checked %v in %s, want checked", bold.Checked(), d.Page())
+	}
+	assertPageInSync(t, d)
+
+	d.Click(bold.ID())
+	if bold.Checked() || strings.Contains(d.Page(), "checked") {
+		t.Errorf("item checked %v in %s, want unchecked", bold.Checked(), d.Page())
+	}
+	assertPageInSync(t, d)
+}
+
+func TestSubMenuSelect(t *testing.T) {
+	pasted := 0
+	paste := MenuItem("Paste").OnSelect(func() {
+		pasted++
+	})
+	edit := SubMenu("Edit", paste)
+	parentSelected := 0
+	edit.OnSelect(func() {
+		parentSelected++
+	})
+	var subSelected, selected []*MenuItemWidget
+	edit.SubMenu().OnSelect(func(item *MenuItemWidget) {
+		subSelected = append(subSelected, item)
+	})
+	menu := Menu(edit).OnSelect(func(item *MenuItemWidget) {
+		selected = append(selected, item)
+	})
+	d := odentest.New(t, menu)
+
+	// Clicking the parent opens its submenu rather than selecting it.
+	d.Click(edit.ID())
+	if parentSelected != 0 || len(selected) != 0 {
+		t.Errorf("submenu parent selected %d times, menu got %v", parentSelected, selected)
+	}
+
+	d.Click(paste.ID())
+	if pasted != 1 || parentSelected != 0 {
+		t.Errorf("item selected %d times and its parent %d times, want 1 and 0", pasted, parentSelected)
+	}
+	if len(subSelected) != 1 || subSelected[0] != paste || len(selected) != 1 || selected[0] != paste {
+		t.Errorf("submenu got %v and menu got %v, want the item in both", subSelected, selected)
+	}
+}
+
+func TestMenuOwnersAttachMenu(t *testing.T) {
+	tests := []struct {
+		name  string
+		owner func(menu *MenuWidget) Widget
+	}{
+		{"Dropdown", func(menu *MenuWidget) Widget {
+			return Dropdown(Button("Menu"), menu)
+		}},
+		{"ContextMenu", func(menu *MenuWidget) Widget {
+			return ContextMenu(Text(State("area")), menu)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected := 0
+			checked := State(false)
+			item := CheckMenuItemWithModel("Wrap", checked).OnSelect(func() {
+				selected++
+			})
+			owner := tt.owner(Menu(item))
+			root := Column(owner)
+			d := odentest.New(t, root)
+
+			d.Click(item.ID())
+			if selected != 1 || !checked.Get() {
+				t.Errorf("item selected %d times, checked %v, want once and checked", selected, checked.Get())
+			}
+			assertPageInSync(t, d)
+
+			d.Do(func() {
+				root.Remove(owner)
+			})
+			d.Updates()
+			d.Click(item.ID())
+			d.Do(func() {
+				checked.Set(false)
+			})
+			if selected != 1 {
+				t.Errorf("item of a removed menu selected %d times, want once", selected)
+			}
+			if updates := d.Updates(); len(updates) != 0 {
+				t.Errorf("updates %v sent for a removed menu", updates)
+			}
+		})
+	}
+}
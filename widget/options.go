@@ -0,0 +1,161 @@
+package widget
+
+// Option is one of the choices of a Select or a RadioGroup.
+type Option[T comparable] struct {
+	Label    string
+	Value    T
+	Disabled bool
+}
+
+// NewOption returns an enabled option showing label for value.
+func NewOption[T comparable](label string, value T) Option[T] {
+	return Option[T]{
+		Label: label,
+		Value: value,
+	}
+}
+
+// OptionsModel holds the options of a Select or a RadioGroup and the values
+// of the selected ones. Only one option is selected at a time unless the
+// model is multiple.
+type OptionsModel[T comparable] struct {
+	Model
+	options  []Option[T]
+	selected []T
+	multiple bool
+}
+
+func NewOptionsModel[T comparable](options ...Option[T]) *OptionsModel[T] {
+	return &OptionsModel[T]{
+		Model:   NewModel(),
+		options: options,
+	}
+}
+
+// NewMultiOptionsModel returns a model whose options can be selected together.
+func NewMultiOptionsModel[T comparable](options ...Option[T]) *OptionsModel[T] {
+	m := NewOptionsModel(options...)
+	m.multiple = true
+	return m
+}
+
+func (m *OptionsModel[T]) Options() []Option[T] {
+	return m.options
+}
+
+// SetOptions replaces the options. The selected values which are still
+// options stay selected.
+func (m *OptionsModel[T]) SetOptions(options ...Option[T]) {
+	m.options = options
+	m.selected = m.filter(m.selected)
+	m.Notify()
+}
+
+func (m *OptionsModel[T]) AddOption(option Option[T]) {
+	m.options = append(m.options, option)
+	m.Notify()
+}
+
+// SetDisabled disables or enables the option of value.
+func (m *OptionsModel[T]) SetDisabled(value T, disabled bool) {
+	for i := range m.options {
+		if m.options[i].Value == value {
+			m.options[i].Disabled = disabled
+		}
+	}
+	m.Notify()
+}
+
+func (m *OptionsModel[T]) Multiple() bool {
+	return m.multiple
+}
+
+// SetMultiple allows several options to be selected together or not.
+// Only the first selected value is kept when multiple is false.
+func (m *OptionsModel[T]) SetMultiple(multiple bool) {
+	m.multiple = multiple
+	if !multiple && len(m.selected) > 1 {
+		m.selected = m.selected[:1]
+	}
+	m.Notify()
+}
+
+// Selected returns the first selected value
+// and whether any value is selected.
+func (m *OptionsModel[T]) Selected() (T, bool) {
+	if len(m.selected) == 0 {
+		var zero T
+		return zero, false
+	}
+	return m.selected[0], true
+}
+
+// SelectedValues returns the selected values in the order of the options.
+func (m *OptionsModel[T]) SelectedValues() []T {
+	return append([]T(nil), m.selected...)
+}
+
+// SetSelected selects the options of values and deselects the others.
+// Values which are not options are ignored, and so are the values after
+// the first one unless the model is multiple.
+func (m *OptionsModel[T]) SetSelected(values ...T) {
+	m.selected = m.filter(values)
+	m.Notify()
+}
+
+// IsSelected reports whether the option of value is selected.
+func (m *OptionsModel[T]) IsSelected(value T) bool {
+	for _, v := range m.selected {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// filter returns the values of the options in values, in the order of the options.
+func (m *OptionsModel[T]) filter(values []T) []T {
+	if !m.multiple && len(values) > 1 {
+		values = values[:1]
+	}
+	var selected []T
+	for _, o := range m.options {
+		for _, v := range values {
+			if o.Value == v {
+				selected = append(selected, v)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// selectIndices selects the options at indices, as reported by the browser,
+// and reports whether the selection changed.
+func (m *OptionsModel[T]) selectIndices(indices []int) bool {
+	var values []T
+	for _, i := range indices {
+		if i >= 0 && i < len(m.options) {
+			values = append(values, m.options[i].Value)
+		}
+	}
+	selected := m.filter(values)
+	if equalValues(selected, m.selected) {
+		return false
+	}
+	m.selected = selected
+	m.Notify()
+	return true
+}
+
+func equalValues[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package widget
+
+import core "github.com/i2y/oden/core"
+
+// Alert shows a message in a dialog of the main window of app
+// and blocks until the user closes it.
+//
+// Like Confirm and Prompt, Alert may be called from any goroutine.
+// Called from an event handler, it keeps the App running until it returns;
+// see core.App.Wait.
+func Alert(app *core.App, title, message string) {
+	ask(app, title, "", "OK", func(func(bool)) Widget {
+		return Text(State(message))
+	}, nil)
+}
+
+// Confirm shows a message in a dialog of the main window of app and blocks
+// until the user answers. It reports whether the user chose OK.
+func Confirm(app *core.App, title, message string) bool {
+	return ask(app, title, "Cancel", "OK", func(func(bool)) Widget {
+		return Text(State(message))
+	}, nil)
+}
+
+// Prompt shows a message and an input initially holding value in a dialog
+// of the main window of app, and blocks until the user answers.
+// It returns the entered text and whether the user chose OK.
+func Prompt(app *core.App, title, message, value string) (string, bool) {
+	text := State(value)
+	var entered string
+	ok := ask(app, title, "Cancel", "OK", func(answer func(bool)) Widget {
+		input := InputWithState(TextInputType, "", text)
+		input.OnKeyDown(func(ev core.Event) {
+			if ev.Key() == "Enter" {
+				answer(true)
+			}
+		})
+		return Column(Text(State(message)), input)
+	}, func() {
+		entered = text.Get()
+	})
+	return entered, ok
+}
+
+// ask shows a dialog with the body built by body and the buttons labelled
+// cancel, unless it is empty, and ok. It blocks until one of the buttons is
+// clicked, or the dialog is dismissed as if cancel was clicked.
+// body may answer by itself, e.g. when Enter is pressed.
+// onAnswer, unless nil, is called on the UI goroutine when the user answers,
+// so that it can read the models of the body.
+func ask(app *core.App, title, cancel, ok string, body func(answer func(bool)) Widget, onAnswer func()) bool {
+	answered := make(chan struct{})
+	var result bool
+	// answer is called on the UI goroutine.
+	answer := func(r bool) {
+		select {
+		case <-answered:
+		default:
+			result = r
+			if onAnswer != nil {
+				onAnswer()
+			}
+			close(answered)
+		}
+	}
+
+	var dialog *DialogWidget
+	var remove func()
+	app.Dispatch(func() {
+		var buttons []Widget
+		if cancel != "" {
+			buttons = append(buttons, Button(cancel).OnClick(func(core.Event) {
+				answer(false)
+			}))
+		}
+		buttons = append(buttons, Button(ok, Type(Primary)).OnClick(func(core.Event) {
+			answer(true)
+		}))
+		dialog = DialogWithModel(State(true), title, body(answer), buttons...)
+		dialog.OnRequestClose(func() bool {
+			answer(false)
+			return true
+		})
+		dialog.Attach(app)
+		remove = app.MainWindow().AddOverlay(dialog)
+	})
+	app.Wait(answered)
+
+	app.Dispatch(func() {
+		remove()
+		dialog.Detach()
+	})
+	return result
+}
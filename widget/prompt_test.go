@@ -0,0 +1,60 @@
+package widget
+
+import (
+	"testing"
+	"time"
+
+	core "github.com/i2y/oden/core"
+	"github.com/i2y/oden/core/odentest"
+)
+
+// waitFor returns the ID of the widget rendering the first element matching
+// query, waiting for a goroutine to show it.
+func waitFor(t *testing.T, d *odentest.Driver, query string) core.WidgetID {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if ids := d.FindAll(query); len(ids) > 0 {
+			return ids[0]
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no element matching %s", query)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	d := odentest.New(t, Text(State("main")))
+
+	type answer struct {
+		text string
+		ok   bool
+	}
+	answers := make(chan answer)
+	ask := func() {
+		go func() {
+			text, ok := Prompt(d.App(), "Name", "Your name?", "Ann")
+			answers <- answer{text, ok}
+		}()
+	}
+
+	ask()
+	input := waitFor(t, d, `sl-input[value=Ann]`)
+	d.Input(input, "Bob")
+	d.KeyDown(input, "Enter", 0)
+	if a := <-answers; a != (answer{"Bob", true}) {
+		t.Errorf("Prompt() = %q, %v, want Bob, true", a.text, a.ok)
+	}
+	if ids := d.FindAll(`sl-input`); len(ids) != 0 {
+		t.Errorf("dialog still shown: %v", ids)
+	}
+
+	ask()
+	waitFor(t, d, `sl-input[value=Ann]`)
+	d.Click(d.Find(`sl-button:contains(Cancel)`))
+	if a := <-answers; a.ok {
+		t.Errorf("Prompt() = %q, %v after Cancel, want false", a.text, a.ok)
+	}
+}
@@ -0,0 +1,168 @@
+package widget
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	core "github.com/i2y/oden/core"
+)
+
+type RadioGroupWidget[T comparable] struct {
+	Base
+	model    *OptionsModel[T]
+	label    string
+	radios   []*radioWidget[T]
+	onSelect func(value T)
+}
+
+// RadioGroup returns a group of radio buttons, one for each option,
+// one of which can be selected.
+func RadioGroup[T comparable](label string, options ...Option[T]) *RadioGroupWidget[T] {
+	return RadioGroupWithModel(NewOptionsModel(options...), label)
+}
+
+// RadioGroupWithModel returns a radio group of the options of m,
+// which should not be multiple.
+func RadioGroupWithModel[T comparable](m *OptionsModel[T], label string) *RadioGroupWidget[T] {
+	g := &RadioGroupWidget[T]{
+		Base:  NewBase(),
+		model: m,
+		label: label,
+	}
+	g.listen(m)
+	g.Base.SetWidget(g)
+	g.syncRadios()
+	return g
+}
+
+func (g *RadioGroupWidget[T]) Attach(a *core.App) {
+	g.Base.Attach(a)
+
+	g.syncRadios()
+	for _, r := range g.radios {
+		r.Attach(a)
+	}
+}
+
+func (g *RadioGroupWidget[T]) Detach() {
+	g.Base.Detach()
+
+	for _, r := range g.radios {
+		r.Detach()
+	}
+}
+
+// Update makes a radio for each option, since the options of the model may
+// have changed, and re-renders the group.
+func (g *RadioGroupWidget[T]) Update() {
+	g.syncRadios()
+	g.Base.Update()
+}
+
+func (g *RadioGroupWidget[T]) View() string {
+	var radios strings.Builder
+	for _, r := range g.radios {
+		// The options may have been removed while the group was detached.
+		if r.index < len(g.model.options) {
+			radios.WriteString(r.View())
+		}
+	}
+	return fmt.Sprintf(
+		`<sl-radio-group id="%s" style="%s %s" label="%s" fieldset>%s</sl-radio-group>
+		 <style>sl-radio-group#%s::part(base) {%s}</style>`,
+		g.ID(),
+		g.SizeStyle(),
+		g.OtherStyle(),
+		html.EscapeString(g.label),
+		radios.String(),
+
+		g.ID(),
+		g.TextStyle(),
+	)
+}
+
+// syncRadios makes a radio for each option. Radios are widgets so that
+// the browser reports which one is clicked.
+func (g *RadioGroupWidget[T]) syncRadios() {
+	n := len(g.model.options)
+	for i := len(g.radios); i < n; i++ {
+		r := newRadio(g, i)
+		if g.attached {
+			r.Attach(g.app)
+		}
+		g.radios = append(g.radios, r)
+	}
+	for _, r := range g.radios[n:] {
+		r.Detach()
+	}
+	g.radios = g.radios[:n]
+}
+
+// Selected returns the selected value and whether an option is selected.
+func (g *RadioGroupWidget[T]) Selected() (T, bool) {
+	return g.model.Selected()
+}
+
+func (g *RadioGroupWidget[T]) SetSelected(value T) *RadioGroupWidget[T] {
+	g.model.SetSelected(value)
+	return g
+}
+
+// OnSelect sets the function called with the selected value
+// when the user selects an option.
+func (g *RadioGroupWidget[T]) OnSelect(handler func(value T)) *RadioGroupWidget[T] {
+	g.onSelect = handler
+	return g
+}
+
+func (g *RadioGroupWidget[T]) receiveSelection(index int) {
+	var changed bool
+	g.receive(func() {
+		changed = g.model.selectIndices([]int{index})
+	})
+	if !changed || g.onSelect == nil {
+		return
+	}
+	if value, ok := g.model.Selected(); ok {
+		g.onSelect(value)
+	}
+}
+
+// radioWidget is the radio of the option at index in a radio group.
+type radioWidget[T comparable] struct {
+	Base
+	group *RadioGroupWidget[T]
+	index int
+}
+
+func newRadio[T comparable](g *RadioGroupWidget[T], index int) *radioWidget[T] {
+	r := &radioWidget[T]{
+		Base:  NewBase(),
+		group: g,
+		index: index,
+	}
+	r.Base.SetWidget(r)
+	r.On("sl-change", r.receiveChecked)
+	return r
+}
+
+func (r *radioWidget[T]) View() string {
+	o := r.group.model.options[r.index]
+	return fmt.Sprintf(
+		`<sl-radio id="%s" name="%s" value="%d" %s %s>%s</sl-radio>`,
+		r.ID(),
+		r.group.ID(),
+		r.index,
+		checkedAttr(r.group.model.IsSelected(o.Value)),
+		disabledAttr(o.Disabled),
+		html.EscapeString(o.Label),
+	)
+}
+
+func (r *radioWidget[T]) receiveChecked(ev core.Event) {
+	if checked, ok := ev.BoolProp("checked"); !ok || !checked {
+		return
+	}
+	r.group.receiveSelection(r.index)
+}
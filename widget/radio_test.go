@@ -0,0 +1,58 @@
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/i2y/oden/core/odentest"
+)
+
+func TestRadioGroupOptions(t *testing.T) {
+	m := NewOptionsModel(NewOption("Small", 1), NewOption("Large", 2))
+	g := RadioGroupWithModel(m, "Size")
+	var selected []int
+	g.OnSelect(func(v int) {
+		selected = append(selected, v)
+	})
+	d := odentest.New(t, g)
+
+	d.Do(func() {
+		m.AddOption(NewOption("Huge", 3))
+	})
+	if len(g.radios) != 3 {
+		t.Fatalf("%d radios, want 3", len(g.radios))
+	}
+	// The radio of the new option is attached before being rendered,
+	// so that it receives its events.
+	d.Change(g.radios[2].ID(), map[string]interface{}{"checked": true})
+	if v, ok := g.Selected(); !ok || v != 3 || len(selected) != 1 {
+		t.Errorf("Selected() = %v, %v with %v reported, want 3", v, ok, selected)
+	}
+	assertPageInSync(t, d)
+
+	d.Do(func() {
+		m.SetOptions(NewOption("Small", 1))
+	})
+	if len(g.radios) != 1 || strings.Contains(d.Page(), "Huge") {
+		t.Errorf("%d radios shown in %s, want the small one", len(g.radios), d.Page())
+	}
+	assertPageInSync(t, d)
+}
+
+func TestRadioGroupViewIsPure(t *testing.T) {
+	m := NewOptionsModel(NewOption("Small", 1))
+	g := RadioGroupWithModel(m, "Size")
+	m.AddOption(NewOption("Large", 2))
+
+	// Detached, the group doesn't listen to the model, and rendering it
+	// doesn't make the missing radio.
+	g.View()
+	if len(g.radios) != 1 {
+		t.Errorf("%d radios after View, want 1", len(g.radios))
+	}
+
+	d := odentest.New(t, g)
+	if len(g.radios) != 2 || !strings.Contains(d.Page(), "Large") {
+		t.Errorf("%d radios shown in %s after Attach, want 2", len(g.radios), d.Page())
+	}
+}
@@ -0,0 +1,170 @@
+package widget
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"reflect"
+
+	core "github.com/i2y/oden/core"
+)
+
+// Number is the constraint of the types of the values of a range.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// maxTooltipLabels is the maximum number of the values of a range whose
+// labels are sent to the browser. The tooltip of a range with more values
+// shows the values as numbers.
+const maxTooltipLabels = 1000
+
+type RangeWidget[T Number] struct {
+	Base
+	model    ValuePublisher[T]
+	min      T
+	max      T
+	step     T
+	tooltip  string
+	format   func(T) string
+	disabled bool
+}
+
+// Range returns a slider choosing a value from min to max.
+func Range[T Number](value, min, max T) *RangeWidget[T] {
+	return RangeWithModel[T](State(value), min, max)
+}
+
+func RangeWithModel[T Number](v ValuePublisher[T], min, max T) *RangeWidget[T] {
+	r := &RangeWidget[T]{
+		Base:    NewBase(),
+		model:   v,
+		min:     min,
+		max:     max,
+		step:    1,
+		tooltip: "top",
+	}
+	r.listen(v)
+	r.Base.SetWidget(r)
+	r.On("sl-change", r.receiveValue)
+	return r
+}
+
+func (r *RangeWidget[T]) View() string {
+	return fmt.Sprintf(
+		`<sl-range id="%s" style="%s %s" value="%v" min="%v" max="%v" step="%v" tooltip="%s" %s %s></sl-range>`,
+		r.ID(),
+		r.SizeStyle(),
+		r.OtherStyle(),
+		r.model.Get(),
+		r.min,
+		r.max,
+		r.step,
+		r.tooltip,
+		r.tooltipLabels(),
+		disabledAttr(r.disabled),
+	)
+}
+
+// tooltipLabels returns the attribute holding the labels of the values
+// formatted by the tooltip formatter; see assets/oden.js.
+func (r *RangeWidget[T]) tooltipLabels() string {
+	if r.format == nil || r.step <= 0 || r.max < r.min {
+		return ""
+	}
+	n := float64(r.max-r.min) / float64(r.step)
+	if n > maxTooltipLabels {
+		return ""
+	}
+	// The epsilon keeps the last value when the division is slightly off,
+	// e.g. with a step of 0.1.
+	labels := make([]string, int(n+1e-9)+1)
+	for i := range labels {
+		labels[i] = r.format(r.min + T(i)*r.step)
+	}
+	b, err := json.Marshal(labels)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf(`data-tooltip-labels="%s"`, html.EscapeString(string(b)))
+}
+
+func (r *RangeWidget[T]) Value() T {
+	return r.model.Get()
+}
+
+func (r *RangeWidget[T]) SetValue(value T) *RangeWidget[T] {
+	r.model.Set(value)
+	return r
+}
+
+// Step sets the interval between the values of the range, 1 by default.
+func (r *RangeWidget[T]) Step(step T) *RangeWidget[T] {
+	r.step = step
+	r.Update()
+	return r
+}
+
+// Tooltip sets where the tooltip showing the value is placed while the
+// slider is dragged: TopPlacement, the default, or BottomPlacement.
+func (r *RangeWidget[T]) Tooltip(placement Placement) *RangeWidget[T] {
+	r.tooltip = "top"
+	if placement == BottomPlacement {
+		r.tooltip = "bottom"
+	}
+	r.Update()
+	return r
+}
+
+func (r *RangeWidget[T]) NoTooltip() *RangeWidget[T] {
+	r.tooltip = "none"
+	r.Update()
+	return r
+}
+
+// TooltipFormat sets the function formatting the values shown in the tooltip.
+func (r *RangeWidget[T]) TooltipFormat(f func(T) string) *RangeWidget[T] {
+	r.format = f
+	r.Update()
+	return r
+}
+
+func (r *RangeWidget[T]) Disable() *RangeWidget[T] {
+	r.disabled = true
+	r.Update()
+	return r
+}
+
+func (r *RangeWidget[T]) Enable() *RangeWidget[T] {
+	r.disabled = false
+	r.Update()
+	return r
+}
+
+func (r *RangeWidget[T]) receiveValue(ev core.Event) {
+	value, ok := ev.NumberProp("value")
+	if !ok {
+		return
+	}
+	// Values of integer types are rounded rather than truncated.
+	v := T(value)
+	if isInteger[T]() {
+		v = T(math.Round(value))
+	}
+	r.receive(func() {
+		r.model.Set(v)
+	})
+}
+
+// isInteger reports whether T is an integer type rather than a floating-point one.
+func isInteger[T Number]() bool {
+	var zero T
+	switch reflect.TypeOf(zero).Kind() {
+	case reflect.Float32, reflect.Float64:
+		return false
+	}
+	return true
+}
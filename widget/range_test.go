@@ -0,0 +1,114 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/i2y/oden/core/odentest"
+)
+
+type percent int
+
+func TestRangeReceiveValue(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		value := State(percent(50))
+		r := RangeWithModel[percent](value, 0, 100)
+		label := Text(value)
+		d := odentest.New(t, Column(r, label))
+
+		d.Change(r.ID(), map[string]interface{}{"value": 75.0})
+		if value.Get() != 75 {
+			t.Errorf("value = %v, want 75", value.Get())
+		}
+		assertPageInSync(t, d)
+
+		// Values between the integers are rounded rather than truncated.
+		for _, tt := range []struct {
+			value float64
+			want  percent
+		}{
+			{2.6, 3},
+			{2.4, 2},
+			{-1.5, -2},
+		} {
+			d.Change(r.ID(), map[string]interface{}{"value": tt.value})
+			if value.Get() != tt.want {
+				t.Errorf("value %v received as %v, want %v", tt.value, value.Get(), tt.want)
+			}
+		}
+	})
+
+	t.Run("uint8", func(t *testing.T) {
+		r := Range[uint8](0, 0, 255)
+		d := odentest.New(t, r)
+
+		d.Change(r.ID(), map[string]interface{}{"value": 127.5})
+		if r.Value() != 128 {
+			t.Errorf("value = %v, want 128", r.Value())
+		}
+	})
+
+	t.Run("float", func(t *testing.T) {
+		r := Range(0.5, 0, 1).Step(0.05)
+		d := odentest.New(t, r)
+
+		d.Change(r.ID(), map[string]interface{}{"value": 0.35})
+		if r.Value() != 0.35 {
+			t.Errorf("value = %v, want 0.35", r.Value())
+		}
+		assertPageInSync(t, d)
+	})
+
+	t.Run("float32", func(t *testing.T) {
+		r := Range[float32](0, 0, 10).Step(0.5)
+		d := odentest.New(t, r)
+
+		d.Change(r.ID(), map[string]interface{}{"value": 2.5})
+		if r.Value() != 2.5 {
+			t.Errorf("value = %v, want 2.5", r.Value())
+		}
+	})
+
+	t.Run("not a number", func(t *testing.T) {
+		r := Range(3, 0, 10)
+		d := odentest.New(t, r)
+
+		d.Change(r.ID(), map[string]interface{}{"value": "5"})
+		if r.Value() != 3 {
+			t.Errorf("value = %v, want 3", r.Value())
+		}
+	})
+}
+
+func TestRatingReceiveValue(t *testing.T) {
+	value := State(2.0)
+	r := RatingWithModel(value).Precision(0.5)
+	d := odentest.New(t, Column(r, Text(value)))
+
+	d.Change(r.ID(), map[string]interface{}{"value": 3.5})
+	if value.Get() != 3.5 {
+		t.Errorf("value = %v, want 3.5", value.Get())
+	}
+	assertPageInSync(t, d)
+
+	d.Change(r.ID(), map[string]interface{}{"value": "4"})
+	if value.Get() != 3.5 {
+		t.Errorf("value = %v after a malformed event, want 3.5", value.Get())
+	}
+}
+
+func TestColorPickerReceiveValue(t *testing.T) {
+	color := State("#ffffff")
+	c := ColorPickerWithModel(color)
+	d := odentest.New(t, Column(c, Text(color)))
+
+	d.Change(c.ID(), map[string]interface{}{"value": "#4a90e2"})
+	if color.Get() != "#4a90e2" || c.Value() != "#4a90e2" {
+		t.Errorf("color = %q, want #4a90e2", color.Get())
+	}
+	assertPageInSync(t, d)
+
+	d.Change(c.ID(), map[string]interface{}{"value": 1.0})
+	if color.Get() != "#4a90e2" {
+		t.Errorf("color = %q after a malformed event, want #4a90e2", color.Get())
+	}
+}
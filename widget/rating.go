@@ -0,0 +1,109 @@
+package widget
+
+import (
+	"fmt"
+
+	core "github.com/i2y/oden/core"
+)
+
+type RatingWidget struct {
+	Base
+	model     ValuePublisher[float64]
+	max       int
+	precision float64
+	readonly  bool
+	disabled  bool
+}
+
+// Rating returns a row of stars showing value, which the user can change
+// by clicking them.
+func Rating(value float64) *RatingWidget {
+	return RatingWithModel(State(value))
+}
+
+func RatingWithModel(v ValuePublisher[float64]) *RatingWidget {
+	r := &RatingWidget{
+		Base:      NewBase(),
+		model:     v,
+		max:       5,
+		precision: 1,
+	}
+	r.listen(v)
+	r.Base.SetWidget(r)
+	r.On("sl-change", r.receiveValue)
+	return r
+}
+
+func (r *RatingWidget) View() string {
+	return fmt.Sprintf(
+		`<sl-rating id="%s" style="%s %s" value="%v" max="%d" precision="%v" %s %s></sl-rating>`,
+		r.ID(),
+		r.SizeStyle(),
+		r.OtherStyle(),
+		r.model.Get(),
+		r.max,
+		r.precision,
+		readonlyAttr(r.readonly),
+		disabledAttr(r.disabled),
+	)
+}
+
+func (r *RatingWidget) Value() float64 {
+	return r.model.Get()
+}
+
+func (r *RatingWidget) SetValue(value float64) *RatingWidget {
+	r.model.Set(value)
+	return r
+}
+
+// Max sets the number of stars, 5 by default.
+func (r *RatingWidget) Max(max int) *RatingWidget {
+	r.max = max
+	r.Update()
+	return r
+}
+
+// Precision sets the fraction of a star the value is rounded to, e.g. 0.5
+// for half stars. It is 1 by default.
+func (r *RatingWidget) Precision(precision float64) *RatingWidget {
+	r.precision = precision
+	r.Update()
+	return r
+}
+
+// ReadOnly makes the rating only show the value.
+func (r *RatingWidget) ReadOnly() *RatingWidget {
+	r.readonly = true
+	r.Update()
+	return r
+}
+
+func (r *RatingWidget) Disable() *RatingWidget {
+	r.disabled = true
+	r.Update()
+	return r
+}
+
+func (r *RatingWidget) Enable() *RatingWidget {
+	r.disabled = false
+	r.Update()
+	return r
+}
+
+func (r *RatingWidget) receiveValue(ev core.Event) {
+	value, ok := ev.NumberProp("value")
+	if !ok {
+		return
+	}
+	r.receive(func() {
+		r.model.Set(value)
+	})
+}
+
+func readonlyAttr(readonly bool) string {
+	if readonly {
+		return "readonly"
+	}
+	return ""
+}
@@ -0,0 +1,154 @@
+package widget
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	core "github.com/i2y/oden/core"
+)
+
+type SelectWidget[T comparable] struct {
+	Base
+	model          *OptionsModel[T]
+	placeholder    string
+	onSelect       func(value T)
+	onSelectValues func(values []T)
+}
+
+// Select returns a drop-down list of options, one of which can be selected.
+func Select[T comparable](options ...Option[T]) *SelectWidget[T] {
+	return SelectWithModel(NewOptionsModel(options...))
+}
+
+// MultiSelect returns a drop-down list of options, several of which can be selected.
+func MultiSelect[T comparable](options ...Option[T]) *SelectWidget[T] {
+	return SelectWithModel(NewMultiOptionsModel(options...))
+}
+
+func SelectWithModel[T comparable](m *OptionsModel[T]) *SelectWidget[T] {
+	s := &SelectWidget[T]{
+		Base:  NewBase(),
+		model: m,
+	}
+	s.listen(m)
+	s.Base.SetWidget(s)
+	s.On("sl-change", s.receiveValue)
+	return s
+}
+
+// The values of the menu items are the indices of the options, which are
+// mapped back to the typed values when the selection changes. The selection
+// of a multiple select is rendered in data-values, as its value can't be given
+// by an attribute; see assets/oden.js.
+func (s *SelectWidget[T]) View() string {
+	var items strings.Builder
+	var selected []string
+	for i, o := range s.model.options {
+		if s.model.IsSelected(o.Value) {
+			selected = append(selected, strconv.Itoa(i))
+		}
+		fmt.Fprintf(
+			&items,
+			`<sl-menu-item value="%d" %s>%s</sl-menu-item>`,
+			i,
+			disabledAttr(o.Disabled),
+			html.EscapeString(o.Label),
+		)
+	}
+
+	value := fmt.Sprintf(`value="%s"`, strings.Join(selected, ""))
+	if s.model.multiple {
+		value = fmt.Sprintf(`multiple data-values="%s"`, strings.Join(selected, " "))
+	}
+	return fmt.Sprintf(
+		`<sl-select id="%s" style="%s %s" placeholder="%s" %s size="medium" hoist>%s</sl-select>
+		 <style>sl-select#%s::part(display-label) {%s}</style>`,
+		s.ID(),
+		s.SizeStyle(),
+		s.OtherStyle(),
+		html.EscapeString(s.placeholder),
+		value,
+		items.String(),
+
+		s.ID(),
+		s.TextStyle(),
+	)
+}
+
+// Placeholder sets the text shown while no option is selected.
+func (s *SelectWidget[T]) Placeholder(placeholder string) *SelectWidget[T] {
+	s.placeholder = placeholder
+	s.Update()
+	return s
+}
+
+// Selected returns the selected value and whether an option is selected.
+func (s *SelectWidget[T]) Selected() (T, bool) {
+	return s.model.Selected()
+}
+
+// SelectedValues returns the selected values.
+func (s *SelectWidget[T]) SelectedValues() []T {
+	return s.model.SelectedValues()
+}
+
+func (s *SelectWidget[T]) SetSelected(values ...T) *SelectWidget[T] {
+	s.model.SetSelected(values...)
+	return s
+}
+
+// OnSelect sets the function called with the selected value
+// when the user selects an option.
+func (s *SelectWidget[T]) OnSelect(handler func(value T)) *SelectWidget[T] {
+	s.onSelect = handler
+	return s
+}
+
+// OnSelectValues sets the function called with the selected values
+// when the user changes the selection, e.g. of a MultiSelect.
+func (s *SelectWidget[T]) OnSelectValues(handler func(values []T)) *SelectWidget[T] {
+	s.onSelectValues = handler
+	return s
+}
+
+func (s *SelectWidget[T]) receiveValue(ev core.Event) {
+	var values []string
+	if value, ok := ev.StringProp("value"); ok {
+		if value != "" {
+			values = []string{value}
+		}
+	} else if values, ok = ev.StringsProp("value"); !ok {
+		return
+	}
+
+	var indices []int
+	for _, v := range values {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			continue
+		}
+		indices = append(indices, i)
+	}
+	var changed bool
+	s.receive(func() {
+		changed = s.model.selectIndices(indices)
+	})
+	if !changed {
+		return
+	}
+	if value, ok := s.model.Selected(); ok && s.onSelect != nil {
+		s.onSelect(value)
+	}
+	if s.onSelectValues != nil {
+		s.onSelectValues(s.model.SelectedValues())
+	}
+}
+
+func disabledAttr(disabled bool) string {
+	if disabled {
+		return "disabled"
+	}
+	return ""
+}
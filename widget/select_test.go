@@ -0,0 +1,70 @@
+package widget
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/i2y/oden/core/odentest"
+)
+
+type size struct {
+	name string
+	cm   int
+}
+
+func TestSelectReceiveValue(t *testing.T) {
+	small, large := size{"S", 10}, size{"L", 30}
+	s := Select(NewOption("Small", small), NewOption("Large", large))
+	var selected []size
+	s.OnSelect(func(v size) {
+		selected = append(selected, v)
+	})
+	d := odentest.New(t, s)
+
+	// The page sends the index of the option, which maps to its value.
+	d.Change(s.ID(), map[string]interface{}{"value": "1"})
+	if v, ok := s.Selected(); !ok || v != large || !reflect.DeepEqual(selected, []size{large}) {
+		t.Errorf("Selected() = %v, %v with %v reported, want %v", v, ok, selected, large)
+	}
+	assertPageInSync(t, d)
+
+	// Selecting the same option again doesn't report it.
+	d.Change(s.ID(), map[string]interface{}{"value": "1"})
+	if len(selected) != 1 {
+		t.Errorf("%v reported, want the large size once", selected)
+	}
+
+	// An empty value deselects the option.
+	d.Change(s.ID(), map[string]interface{}{"value": ""})
+	if v, ok := s.Selected(); ok || len(selected) != 1 {
+		t.Errorf("Selected() = %v, %v with %v reported, want none", v, ok, selected)
+	}
+	assertPageInSync(t, d)
+}
+
+func TestMultiSelectReceiveValue(t *testing.T) {
+	s := MultiSelect(NewOption("Red", 'r'), NewOption("Green", 'g'), NewOption("Blue", 'b'))
+	var selected [][]rune
+	s.OnSelectValues(func(values []rune) {
+		selected = append(selected, values)
+	})
+	d := odentest.New(t, s)
+
+	d.Change(s.ID(), map[string]interface{}{"value": []interface{}{"0", "2"}})
+	if got := s.SelectedValues(); !reflect.DeepEqual(got, []rune{'r', 'b'}) || len(selected) != 1 {
+		t.Errorf("SelectedValues() = %q with %q reported, want red and blue", got, selected)
+	}
+	assertPageInSync(t, d)
+
+	d.Change(s.ID(), map[string]interface{}{"value": []interface{}{}})
+	if got := s.SelectedValues(); len(got) != 0 || len(selected) != 2 || len(selected[1]) != 0 {
+		t.Errorf("SelectedValues() = %q with %q reported, want none", got, selected)
+	}
+	assertPageInSync(t, d)
+
+	// A value that isn't a list of strings is ignored.
+	d.Change(s.ID(), map[string]interface{}{"value": []interface{}{0, 2}})
+	if len(selected) != 2 {
+		t.Errorf("%q reported for a malformed value", selected)
+	}
+}
@@ -0,0 +1,117 @@
+package widget
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/i2y/oden/core/odentest"
+)
+
+func TestSnapshots(t *testing.T) {
+	tests := []struct {
+		name string
+		view func() Widget
+	}{
+		{"Button", func() Widget {
+			return Button("OK")
+		}},
+		{"ButtonWithOptions", func() Widget {
+			return Button("Delete", Type(Dangerous), Shape(Pill))
+		}},
+		{"Checkbox", func() Widget {
+			return Checkbox(true, "I agree").Disable()
+		}},
+		{"ColorPicker", func() Widget {
+			return ColorPicker("#4a90e2").Format(RGBColorFormat).Opacity().Swatches("#000000", "rgb(255, 0, 0)")
+		}},
+		{"Column", func() Widget {
+			return Column(Text(State("a")), Spacer().FixedHeight(10), Text(State("b")).FixedHeight(20))
+		}},
+		{"Component", func() Widget {
+			return NewComponent(func() Widget {
+				return Text(State("built"))
+			})
+		}},
+		{"DataTable", func() Widget {
+			return DataTable(NewTableModel(
+				&HeaderRow{Labels: []string{"Name", "Age"}},
+				[]*DataRow{{Items: []string{"Alice", "30"}}, {Items: []string{"<Bob>", "25"}}},
+			))
+		}},
+		{"ContextMenu", func() Widget {
+			return ContextMenu(Text(State("Right-click me")).FixedHeight(40), Menu(MenuItem("Copy"), MenuItem("Paste").Disable()))
+		}},
+		{"Dialog", func() Widget {
+			return DialogWithModel(State(true), "Delete <file>?", Text(State("It can't be undone.")), Button("Cancel"), Button("Delete", Type(Dangerous)))
+		}},
+		{"Divider", func() Widget {
+			return Divider()
+		}},
+		{"Drawer", func() Widget {
+			return Drawer(StartPlacement, "Settings", Switch(false, "Dark mode")).SetWidth(320)
+		}},
+		{"Dropdown", func() Widget {
+			return Dropdown(Button("File"), Menu(MenuItem("Open"), MenuItem("Save"))).Placement(EndPlacement).StayOpenOnSelect()
+		}},
+		{"ForEach", func() Widget {
+			return Row(ForEach([]string{"x", "y"}, func(s string) Widget {
+				return Text(State(s))
+			})...)
+		}},
+		{"Input", func() Widget {
+			return InputWithState(PasswordInputType, `Say "hi"`, State("secret"))
+		}},
+		{"Menu", func() Widget {
+			return Menu(
+				MenuItem("Undo <last>"),
+				CheckMenuItem("Word wrap", true),
+				SubMenu("Export", MenuItem("PDF"), MenuItem("HTML")),
+			)
+		}},
+		{"MultiSelect", func() Widget {
+			return MultiSelect(NewOption("Go", "go"), NewOption("Rust", "rust"), NewOption("Zig", "zig")).SetSelected("go", "zig")
+		}},
+		{"RadioGroup", func() Widget {
+			return RadioGroup("Size", NewOption("Small", 1), NewOption("Large", 2), Option[int]{Label: "Huge", Value: 3, Disabled: true}).SetSelected(2)
+		}},
+		{"Range", func() Widget {
+			return Range(40, 0, 100).Step(20).TooltipFormat(func(v int) string {
+				return fmt.Sprintf("%d%%", v)
+			})
+		}},
+		{"Rating", func() Widget {
+			return Rating(3.5).Max(10).Precision(0.5).ReadOnly()
+		}},
+		{"Row", func() Widget {
+			return Row(Button("A").FixedWidth(40), Text(State("b")).FixedRatioWidth(50))
+		}},
+		{"Select", func() Widget {
+			return Select(NewOption("<none>", 0), NewOption("One", 1)).Placeholder("Pick one").SetSelected(1)
+		}},
+		{"Spacer", func() Widget {
+			return Spacer()
+		}},
+		{"Switch", func() Widget {
+			return Switch(true, "Enabled")
+		}},
+		{"Tabs", func() Widget {
+			return Tabs(
+				Tab("General", Text(State("general"))),
+				LazyTab("Advanced", func() Widget {
+					return Text(State("advanced"))
+				}).Closable(),
+			).Placement(StartPlacement)
+		}},
+		{"Text", func() Widget {
+			return Text(State("Hello, <world>")).FgColor(PrimaryColor).FontSize(Large).Padding(4)
+		}},
+		{"TextArea", func() Widget {
+			return TextAreaWithState("Notes", State("line 1\nline 2"))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			odentest.Snapshot(t, tt.view())
+		})
+	}
+}
@@ -2,7 +2,9 @@ package widget
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 )
 
 type StringEventPublisher interface {
@@ -11,114 +13,253 @@ type StringEventPublisher interface {
 	SetString(value string)
 }
 
-type StrStateModel struct {
+// ValuePublisher is an EventPublisher that holds a value of type T.
+// Widgets which display or edit a typed value accept a ValuePublisher
+// so that any StateModel[T] (or a type embedding one) can be bound to them.
+type ValuePublisher[T any] interface {
+	EventPublisher
+	Get() T
+	Set(value T)
+}
+
+// StateModel is a model holding a single value of type T.
+// Every change of the value notifies the widgets listening to the model.
+type StateModel[T any] struct {
 	Model
-	value string
+	value  T
+	format func(T) string
+	parse  func(string) (T, error)
 }
 
-func StrState(value string) *StrStateModel {
-	return &StrStateModel{
+// State returns a new StateModel holding the given value.
+func State[T any](value T) *StateModel[T] {
+	s := NewStateModel(value)
+	return &s
+}
+
+// NewStateModel returns a StateModel for embedding in other models.
+func NewStateModel[T any](value T) StateModel[T] {
+	return StateModel[T]{
 		Model: NewModel(),
 		value: value,
 	}
 }
 
-func (s *StrStateModel) String() string {
+func (s *StateModel[T]) Get() T {
 	return s.value
 }
 
-func (s *StrStateModel) SetValue(value string) {
+func (s *StateModel[T]) Set(value T) {
 	s.value = value
 	s.Notify()
 }
 
-func (s *StrStateModel) SetString(value string) {
-	s.value = value
+// Update replaces the value with the result of f applied to the current value.
+func (s *StateModel[T]) Update(f func(T) T) {
+	s.value = f(s.value)
 	s.Notify()
 }
 
-type IntStateModel struct {
-	Model
-	value int
+// Format sets the function used by String to format the value,
+// e.g. for displaying the state in a Text widget.
+// By default the value is formatted with fmt.Sprint.
+func (s *StateModel[T]) Format(f func(T) string) *StateModel[T] {
+	s.format = f
+	return s
 }
 
-func IntState(value int) *IntStateModel {
-	return &IntStateModel{
-		Model: NewModel(),
-		value: value,
+// Parse sets the function used by SetString to parse the value.
+// By default strings are stored as-is and other types are parsed with fmt.Sscan,
+// which has to consume the whole string apart from surrounding spaces.
+func (s *StateModel[T]) Parse(f func(string) (T, error)) *StateModel[T] {
+	s.parse = f
+	return s
+}
+
+func (s *StateModel[T]) String() string {
+	if s.format != nil {
+		return s.format(s.value)
 	}
+	return fmt.Sprint(s.value)
 }
 
-func (i *IntStateModel) SetValue(value int) {
-	i.value = value
-	i.Notify()
+// SetString parses the given string and sets the result as the value.
+// The value is left unchanged if the string cannot be parsed.
+func (s *StateModel[T]) SetString(value string) {
+	v, err := s.parseString(value)
+	if err != nil {
+		return
+	}
+	s.Set(v)
 }
 
-func (i *IntStateModel) Value() int {
-	return i.value
+func (s *StateModel[T]) parseString(value string) (T, error) {
+	if s.parse != nil {
+		return s.parse(value)
+	}
+
+	var v T
+	switch p := any(&v).(type) {
+	case *string:
+		*p = value
+	default:
+		r := strings.NewReader(value)
+		if _, err := fmt.Fscan(r, p); err != nil {
+			return v, err
+		}
+		// Fscan stops at the first character it can't parse, e.g. "12abc"
+		// would be 12.
+		var rest string
+		if n, _ := fmt.Fscan(r, &rest); n > 0 {
+			return v, fmt.Errorf("unexpected %q after the value in %q", rest, value)
+		}
+	}
+	return v, nil
+}
+
+// ComputedModel is a state derived from other states.
+// Its value is recomputed whenever one of its dependencies is notified,
+// and its listeners are notified only if the recomputed value differs
+// from the previous one.
+type ComputedModel[T any] struct {
+	StateModel[T]
+	compute func() T
+	equal   func(a, b T) bool
+	cancels []func()
+}
+
+// Computed returns a state holding the result of compute,
+// which is recomputed every time one of deps changes.
+// Setting a computed state directly overrides its value
+// until one of the dependencies changes. A computed state listens to deps
+// until Stop is called.
+//
+//	price := State(100)
+//	qty := State(3)
+//	total := Computed(func() int { return price.Get() * qty.Get() }, price, qty)
+func Computed[T any](compute func() T, deps ...EventPublisher) *ComputedModel[T] {
+	c := &ComputedModel[T]{
+		StateModel: NewStateModel(compute()),
+		compute:    compute,
+		equal: func(a, b T) bool {
+			return reflect.DeepEqual(a, b)
+		},
+	}
+	for _, d := range deps {
+		c.cancels = append(c.cancels, d.AddListenerFunc(c.recompute))
+	}
+	return c
+}
+
+// Stop stops recomputing the value when the dependencies change, removing
+// the listeners added to them, so that a computed state which is no longer
+// used can be garbage collected even if its dependencies are still in use.
+// The value is kept.
+func (c *ComputedModel[T]) Stop() {
+	for _, cancel := range c.cancels {
+		cancel()
+	}
+	c.cancels = nil
 }
 
-func (i *IntStateModel) String() string {
-	return strconv.Itoa(i.value)
+// Equal sets the function used to decide whether a recomputed value
+// differs from the previous one. By default reflect.DeepEqual is used.
+func (c *ComputedModel[T]) Equal(f func(a, b T) bool) *ComputedModel[T] {
+	c.equal = f
+	return c
 }
 
-func (i *IntStateModel) SetString(value string) {
-	v, _ := strconv.Atoi(value)
-	i.value = v
-	i.Notify()
+// Format is the same as StateModel.Format but returns the ComputedModel for chaining.
+func (c *ComputedModel[T]) Format(f func(T) string) *ComputedModel[T] {
+	c.StateModel.Format(f)
+	return c
+}
+
+func (c *ComputedModel[T]) recompute() {
+	v := c.compute()
+	if c.equal(c.value, v) {
+		return
+	}
+	c.Set(v)
+}
+
+// StrStateModel is kept for backward compatibility. Use State[string] instead.
+type StrStateModel struct {
+	StateModel[string]
+}
+
+func StrState(value string) *StrStateModel {
+	return &StrStateModel{
+		StateModel: NewStateModel(value),
+	}
+}
+
+func (s *StrStateModel) SetValue(value string) {
+	s.Set(value)
+}
+
+// IntStateModel is a StateModel[int] with arithmetic helpers.
+type IntStateModel struct {
+	StateModel[int]
+}
+
+func IntState(value int) *IntStateModel {
+	i := &IntStateModel{
+		StateModel: NewStateModel(value),
+	}
+	i.Parse(func(s string) (int, error) {
+		return strconv.Atoi(strings.TrimSpace(s))
+	})
+	return i
+}
+
+func (i *IntStateModel) SetValue(value int) {
+	i.Set(value)
+}
+
+func (i *IntStateModel) Value() int {
+	return i.Get()
 }
 
 func (i *IntStateModel) Increment() {
-	i.value++
-	i.Notify()
+	i.Add(1)
 }
 
 func (i *IntStateModel) Decrement() {
-	i.value--
-	i.Notify()
+	i.Sub(1)
 }
 
 func (i *IntStateModel) Add(n int) {
-	i.value = i.value + n
-	i.Notify()
+	i.Update(func(v int) int { return v + n })
 }
 
 func (i *IntStateModel) Sub(n int) {
-	i.value = i.value - n
-	i.Notify()
+	i.Update(func(v int) int { return v - n })
 }
 
 func (i *IntStateModel) Mul(n int) {
-	i.value = i.value * n
-	i.Notify()
+	i.Update(func(v int) int { return v * n })
 }
 
 func (i *IntStateModel) Div(n int) {
-	i.value = i.value / n
-	i.Notify()
+	i.Update(func(v int) int { return v / n })
 }
 
+// BoolStateModel is kept for backward compatibility. Use State[bool] instead.
 type BoolStateModel struct {
-	Model
-	value bool
+	StateModel[bool]
 }
 
 func BoolState(value bool) *BoolStateModel {
 	return &BoolStateModel{
-		Model: NewModel(),
-		value: value,
+		StateModel: NewStateModel(value),
 	}
 }
 
-func (b *BoolStateModel) String() string {
-	return strconv.FormatBool(b.value)
-}
-
 func (b *BoolStateModel) Value() bool {
-	return b.value
+	return b.Get()
 }
 
 func (b *BoolStateModel) SetValue(value bool) {
-	b.value = value
-	b.Notify()
+	b.Set(value)
 }
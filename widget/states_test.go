@@ -0,0 +1,131 @@
+package widget
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		s := State(3)
+		s.SetString(s.String())
+		if s.Get() != 3 {
+			t.Errorf("Get() = %d, want 3", s.Get())
+		}
+		s.SetString("-42")
+		if s.Get() != -42 || s.String() != "-42" {
+			t.Errorf("Get() = %d, String() = %q, want -42", s.Get(), s.String())
+		}
+	})
+	t.Run("float", func(t *testing.T) {
+		s := State(2.5)
+		s.SetString(s.String())
+		if s.Get() != 2.5 {
+			t.Errorf("Get() = %v, want 2.5", s.Get())
+		}
+		s.SetString("1e-3")
+		if s.Get() != 0.001 || s.String() != "0.001" {
+			t.Errorf("Get() = %v, String() = %q, want 0.001", s.Get(), s.String())
+		}
+	})
+	t.Run("bool", func(t *testing.T) {
+		s := State(false)
+		s.SetString("true")
+		if !s.Get() || s.String() != "true" {
+			t.Errorf("Get() = %v, String() = %q, want true", s.Get(), s.String())
+		}
+		s.SetString(s.String())
+		if !s.Get() {
+			t.Errorf("Get() = false after a round trip, want true")
+		}
+	})
+	t.Run("IntState", func(t *testing.T) {
+		s := IntState(3)
+		s.SetString(s.String())
+		if s.Get() != 3 {
+			t.Errorf("Get() = %d, want 3", s.Get())
+		}
+		s.SetString(" -42 ")
+		if s.Get() != -42 || s.String() != "-42" {
+			t.Errorf("Get() = %d, String() = %q, want -42", s.Get(), s.String())
+		}
+	})
+	t.Run("string", func(t *testing.T) {
+		s := State("")
+		s.SetString("two words")
+		if s.Get() != "two words" {
+			t.Errorf("Get() = %q, want %q", s.Get(), "two words")
+		}
+	})
+}
+
+func TestStateSetStringInvalid(t *testing.T) {
+	i := State(7)
+	f := State(1.5)
+	b := State(true)
+	legacy := IntState(9)
+	var notified int
+	for _, s := range []StringEventPublisher{i, f, b, legacy} {
+		s.AddListenerFunc(func() { notified++ })
+		s.SetString("not a value")
+		s.SetString("12abc")
+		s.SetString("")
+	}
+	if i.Get() != 7 || f.Get() != 1.5 || !b.Get() || legacy.Get() != 9 {
+		t.Errorf("values = %d, %v, %v, %d, want them unchanged", i.Get(), f.Get(), b.Get(), legacy.Get())
+	}
+	if notified != 0 {
+		t.Errorf("listeners notified %d times, want 0", notified)
+	}
+}
+
+func TestStateFormatAndParse(t *testing.T) {
+	s := State(1500).
+		Format(func(v int) string { return fmt.Sprintf("%d.%03d", v/1000, v%1000) }).
+		Parse(func(s string) (int, error) {
+			var whole, frac int
+			_, err := fmt.Sscanf(s, "%d.%d", &whole, &frac)
+			return whole*1000 + frac, err
+		})
+	if s.String() != "1.500" {
+		t.Errorf("String() = %q, want 1.500", s.String())
+	}
+	s.SetString("2.250")
+	if s.Get() != 2250 {
+		t.Errorf("Get() = %d, want 2250", s.Get())
+	}
+}
+
+func TestComputed(t *testing.T) {
+	price := State(100)
+	qty := State(3)
+	total := Computed(func() int { return price.Get() * qty.Get() }, price, qty)
+	var notified int
+	total.AddListenerFunc(func() { notified++ })
+
+	price.Set(200)
+	if total.Get() != 600 || notified != 1 {
+		t.Errorf("total = %d notified %d times, want 600 notified once", total.Get(), notified)
+	}
+	// An unchanged result doesn't notify the listeners.
+	qty.Set(3)
+	if total.Get() != 600 || notified != 1 {
+		t.Errorf("total = %d notified %d times, want 600 still notified once", total.Get(), notified)
+	}
+}
+
+func TestComputedStop(t *testing.T) {
+	price := State(100)
+	qty := State(3)
+	total := Computed(func() int { return price.Get() * qty.Get() }, price, qty)
+	total.Stop()
+
+	if n := len(price.listeners.list) + len(qty.listeners.list); n != 0 {
+		t.Errorf("%d listeners left on the dependencies, want 0", n)
+	}
+	price.Set(200)
+	if total.Get() != 300 {
+		t.Errorf("total = %d after Stop, want the last value 300", total.Get())
+	}
+	total.Stop()
+}
@@ -2,59 +2,88 @@ package widget
 
 import (
 	"fmt"
+	"html"
+
+	core "github.com/i2y/oden/core"
 )
 
 type SwitchWidget struct {
 	Base
-	model *BoolStateModel
+	model ValuePublisher[bool]
 	label string
 }
 
 func Switch(checked bool, label string) *SwitchWidget {
-	return SwitchWithModel(BoolState(checked), label)
+	return SwitchWithModel(State(checked), label)
 }
 
-func SwitchWithModel(b *BoolStateModel, label string) *SwitchWidget {
+func SwitchWithModel(b ValuePublisher[bool], label string) *SwitchWidget {
 	s := &SwitchWidget{
 		Base:  NewBase(),
 		model: b,
 		label: label,
 	}
-	b.AddListener(s)
+	s.listen(b)
 	s.Base.SetWidget(s)
+	s.On("sl-change", s.receiveChecked)
 	return s
 }
 
 func (s *SwitchWidget) View() string {
 	return fmt.Sprintf(
-		`<sl-switch id="%s" style="%s %s" checked>%s</sl-switch>
+		`<sl-switch id="%s" style="%s %s" %s>%s</sl-switch>
 		 <style>sl-switch#%s::part(base) {%s}</style>`,
 		s.ID(),
 		s.SizeStyle(),
 		s.OtherStyle(),
-		s.label,
+		checkedAttr(s.model.Get()),
+		html.EscapeString(s.label),
 
 		s.ID(),
 		s.TextStyle(),
 	)
 }
 
+func (s *SwitchWidget) Checked() bool {
+	return s.model.Get()
+}
+
+func (s *SwitchWidget) SetChecked(checked bool) *SwitchWidget {
+	s.model.Set(checked)
+	return s
+}
+
+func (s *SwitchWidget) receiveChecked(ev core.Event) {
+	checked, ok := ev.BoolProp("checked")
+	if !ok {
+		return
+	}
+	s.receive(func() {
+		s.model.Set(checked)
+	})
+}
+
+func checkedAttr(checked bool) string {
+	if checked {
+		return "checked"
+	}
+	return ""
+}
+
 type SwitchModel struct {
-	Model
-	checked bool
+	StateModel[bool]
 }
 
 func NewSwitchModel(checked bool) *SwitchModel {
 	return &SwitchModel{
-		Model:   NewModel(),
-		checked: checked,
+		StateModel: NewStateModel(checked),
 	}
 }
 
 func (sm *SwitchModel) Checked() bool {
-	return sm.checked
+	return sm.Get()
 }
 
 func (sm *SwitchModel) SetChecked(checked bool) {
-	sm.checked = checked
+	sm.Set(checked)
 }
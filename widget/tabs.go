@@ -0,0 +1,339 @@
+package widget
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	core "github.com/i2y/oden/core"
+)
+
+type TabsWidget struct {
+	Base
+	tabs      []*TabWidget
+	active    ValuePublisher[string]
+	placement Placement
+	onClose   func(name string) bool
+}
+
+// Tabs returns a container showing the content of one of tabs at a time,
+// the first one initially. The content of a tab is only attached and
+// rendered once the tab is shown, and is kept while other tabs are shown.
+func Tabs(tabs ...*TabWidget) *TabsWidget {
+	var first string
+	if len(tabs) > 0 {
+		first = tabs[0].name
+	}
+	return TabsWithModel(State(first), tabs...)
+}
+
+// TabsWithModel returns tabs showing the tab whose name is active.
+// Names of the tabs should be unique.
+func TabsWithModel(active ValuePublisher[string], tabs ...*TabWidget) *TabsWidget {
+	t := &TabsWidget{
+		Base:      NewBase(),
+		active:    active,
+		placement: TopPlacement,
+	}
+	for _, tab := range tabs {
+		tab.tabs = t
+		t.tabs = append(t.tabs, tab)
+	}
+	t.listen(active)
+	t.Base.SetWidget(t)
+	t.On("sl-tab-show", t.receiveActive)
+	t.showActive()
+	return t
+}
+
+func (t *TabsWidget) Attach(a *core.App) {
+	t.Base.Attach(a)
+
+	t.showActive()
+	for _, tab := range t.tabs {
+		tab.Attach(a)
+	}
+	for _, tab := range t.tabs {
+		if tab.shown {
+			tab.content.Attach(a)
+		}
+	}
+}
+
+func (t *TabsWidget) Detach() {
+	t.Base.Detach()
+
+	for _, tab := range t.tabs {
+		tab.Detach()
+		if tab.shown {
+			tab.content.Detach()
+		}
+	}
+}
+
+// The panel of a tab is named after the ID of the tab. The active panel is
+// also rendered in data-active, which makes the tab group show it; see
+// assets/oden.js.
+func (t *TabsWidget) View() string {
+	active := t.activeTab()
+	var tabs, panels strings.Builder
+	var activePanel string
+	for _, tab := range t.tabs {
+		if tab == active {
+			activePanel = tab.ID().String()
+		}
+		tabs.WriteString(tab.View())
+
+		var content string
+		if tab.shown {
+			content = tab.content.View()
+		}
+		fmt.Fprintf(
+			&panels,
+			`<sl-tab-panel name="%s" style="height: 100%%;" %s>%s</sl-tab-panel>`,
+			tab.ID(),
+			activeAttr(tab == active),
+			content,
+		)
+	}
+	return fmt.Sprintf(
+		`<sl-tab-group id="%s" style="%s %s" placement="%s" data-active="%s">%s%s</sl-tab-group>`,
+		t.ID(),
+		t.SizeStyle(),
+		t.OtherStyle(),
+		t.placement,
+		activePanel,
+		tabs.String(),
+		panels.String(),
+	)
+}
+
+// Update attaches the content of the active tab the first time it is shown,
+// since the active tab may have changed, and re-renders the tabs.
+func (t *TabsWidget) Update() {
+	if tab := t.showActive(); tab != nil && t.attached {
+		tab.content.Attach(t.app)
+	}
+	t.Base.Update()
+}
+
+// showActive marks the active tab as shown and returns it,
+// unless it was already shown.
+func (t *TabsWidget) showActive() *TabWidget {
+	active := t.activeTab()
+	if active == nil || active.shown {
+		return nil
+	}
+	active.shown = true
+	return active
+}
+
+// activeTab returns the tab whose name is the active one, or the first tab.
+func (t *TabsWidget) activeTab() *TabWidget {
+	for _, tab := range t.tabs {
+		if tab.name == t.active.Get() {
+			return tab
+		}
+	}
+	if len(t.tabs) > 0 {
+		return t.tabs[0]
+	}
+	return nil
+}
+
+// Active returns the name of the shown tab.
+func (t *TabsWidget) Active() string {
+	if tab := t.activeTab(); tab != nil {
+		return tab.name
+	}
+	return ""
+}
+
+// SetActive shows the tab with the given name.
+func (t *TabsWidget) SetActive(name string) *TabsWidget {
+	t.active.Set(name)
+	return t
+}
+
+// Placement sets the edge where the tabs are placed, TopPlacement by default.
+func (t *TabsWidget) Placement(placement Placement) *TabsWidget {
+	t.placement = placement
+	t.Update()
+	return t
+}
+
+func (t *TabsWidget) Tabs() []*TabWidget {
+	return t.tabs
+}
+
+// Add adds tab after the other tabs.
+func (t *TabsWidget) Add(tab *TabWidget) {
+	tab.tabs = t
+	t.tabs = append(t.tabs, tab)
+	if t.attached {
+		tab.Attach(t.app)
+	}
+	t.Update()
+}
+
+// Remove removes tab and detaches its content. The next tab is shown
+// if tab was shown.
+func (t *TabsWidget) Remove(tab *TabWidget) {
+	i := t.indexOf(tab)
+	if i < 0 {
+		return
+	}
+	wasActive := tab == t.activeTab()
+	t.tabs = append(t.tabs[:i:i], t.tabs[i+1:]...)
+	if t.attached {
+		tab.Detach()
+		if tab.shown {
+			tab.content.Detach()
+		}
+	}
+	tab.tabs = nil
+	tab.shown = false
+
+	if wasActive && len(t.tabs) > 0 {
+		if i == len(t.tabs) {
+			i--
+		}
+		t.active.Set(t.tabs[i].name)
+	}
+	t.Update()
+}
+
+// OnClose sets the function called with the name of a closable tab when
+// the user closes it. The tab is removed unless handler returns false.
+func (t *TabsWidget) OnClose(handler func(name string) bool) *TabsWidget {
+	t.onClose = handler
+	return t
+}
+
+func (t *TabsWidget) indexOf(tab *TabWidget) int {
+	for i, c := range t.tabs {
+		if c == tab {
+			return i
+		}
+	}
+	return -1
+}
+
+// receiveActive sets the tab shown by the user as the active one. The tabs
+// are re-rendered as usual, since the content of the tab may not have been
+// rendered yet.
+func (t *TabsWidget) receiveActive(ev core.Event) {
+	panel, ok := ev.StringProp("name")
+	if !ok {
+		return
+	}
+	for _, tab := range t.tabs {
+		if tab.ID().String() == panel {
+			t.active.Set(tab.name)
+			return
+		}
+	}
+}
+
+func (t *TabsWidget) close(tab *TabWidget) {
+	if t.onClose != nil && !t.onClose(tab.name) {
+		return
+	}
+	t.Remove(tab)
+}
+
+// TabWidget is a tab of Tabs.
+type TabWidget struct {
+	Base
+	name     string
+	content  Widget
+	tabs     *TabsWidget
+	shown    bool
+	closable bool
+	disabled bool
+}
+
+// Tab returns a tab named name showing content.
+func Tab(name string, content Widget) *TabWidget {
+	tab := &TabWidget{
+		Base:    NewBase(),
+		name:    name,
+		content: content,
+	}
+	tab.Base.SetWidget(tab)
+	tab.On("sl-close", tab.receiveClose)
+	return tab
+}
+
+// LazyTab returns a tab whose content is built by build when the tab is
+// first shown.
+func LazyTab(name string, build func() Widget) *TabWidget {
+	return Tab(name, NewComponent(build))
+}
+
+func (tab *TabWidget) View() string {
+	active := tab.tabs != nil && tab.tabs.activeTab() == tab
+	return fmt.Sprintf(
+		`<sl-tab id="%s" slot="nav" panel="%s" %s %s %s>%s</sl-tab>`,
+		tab.ID(),
+		tab.ID(),
+		activeAttr(active),
+		closableAttr(tab.closable),
+		disabledAttr(tab.disabled),
+		html.EscapeString(tab.name),
+	)
+}
+
+func (tab *TabWidget) Name() string {
+	return tab.name
+}
+
+func (tab *TabWidget) Content() Widget {
+	return tab.content
+}
+
+// Closable shows a close button on the tab; see TabsWidget.OnClose.
+func (tab *TabWidget) Closable() *TabWidget {
+	tab.closable = true
+	tab.updateTabs()
+	return tab
+}
+
+func (tab *TabWidget) Disable() *TabWidget {
+	tab.disabled = true
+	tab.updateTabs()
+	return tab
+}
+
+func (tab *TabWidget) Enable() *TabWidget {
+	tab.disabled = false
+	tab.updateTabs()
+	return tab
+}
+
+// updateTabs re-renders the tabs, which render the panel of the tab too.
+func (tab *TabWidget) updateTabs() {
+	if tab.tabs != nil {
+		tab.tabs.Update()
+	}
+}
+
+func (tab *TabWidget) receiveClose(core.Event) {
+	if tab.tabs != nil {
+		tab.tabs.close(tab)
+	}
+}
+
+func activeAttr(active bool) string {
+	if active {
+		return "active"
+	}
+	return ""
+}
+
+func closableAttr(closable bool) string {
+	if closable {
+		return "closable"
+	}
+	return ""
+}
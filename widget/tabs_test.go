@@ -0,0 +1,73 @@
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	core "github.com/i2y/oden/core"
+	"github.com/i2y/oden/core/odentest"
+)
+
+func TestTabsShowContentOnce(t *testing.T) {
+	builds := 0
+	clicks := 0
+	var button *ButtonWidget
+	a := Tab("A", Text(State("first")))
+	b := LazyTab("B", func() Widget {
+		builds++
+		button = Button("second")
+		button.OnClick(func(core.Event) {
+			clicks++
+		})
+		return button
+	})
+	tabs := Tabs(a, b)
+	d := odentest.New(t, tabs)
+	if strings.Contains(d.Page(), "second") {
+		t.Errorf("content of a hidden tab rendered: %s", d.Page())
+	}
+
+	d.Do(func() {
+		tabs.SetActive("B")
+	})
+	if builds != 1 || strings.Count(d.Page(), "second") != 1 {
+		t.Errorf("content of the shown tab built %d times in %s, want once", builds, d.Page())
+	}
+	// The content is attached, so its handlers receive the events of the page.
+	d.Click(button.ID())
+	if clicks != 1 {
+		t.Errorf("%d clicks on the content of the shown tab, want 1", clicks)
+	}
+	assertPageInSync(t, d)
+
+	// The user shows the first tab again, and then the second one.
+	d.Dispatch(tabs.ID(), "sl-tab-show", map[string]interface{}{"name": a.ID().String()})
+	d.Dispatch(tabs.ID(), "sl-tab-show", map[string]interface{}{"name": b.ID().String()})
+	if tabs.Active() != "B" || builds != 1 || strings.Count(d.Page(), "second") != 1 {
+		t.Errorf("active tab %s, content built %d times in %s, want B shown once", tabs.Active(), builds, d.Page())
+	}
+	d.Click(button.ID())
+	if clicks != 2 {
+		t.Errorf("%d clicks on the content of the shown tab, want 2", clicks)
+	}
+	assertPageInSync(t, d)
+}
+
+func TestTabsViewIsPure(t *testing.T) {
+	active := State("A")
+	b := Tab("B", Text(State("second")))
+	tabs := TabsWithModel(active, Tab("A", Text(State("first"))), b)
+	active.Set("B")
+
+	// Detached, the tabs don't listen to the model, and rendering them
+	// doesn't show the content of the active tab.
+	tabs.View()
+	if b.shown {
+		t.Error("tab shown by View")
+	}
+
+	d := odentest.New(t, tabs)
+	if !b.shown || !strings.Contains(d.Page(), "second") {
+		t.Errorf("active tab not shown after Attach: %s", d.Page())
+	}
+}
@@ -11,7 +11,8 @@ type TextWidget struct {
 	style *TextStyle
 }
 
-// Go 1.18がリリースされたらstring | State[T] のようになるだろう..
+// Text returns a widget displaying s. Any StateModel[T] can be passed as s;
+// its Format function controls how the value is displayed.
 func Text(s StringEventPublisher) *TextWidget {
 	l := &TextWidget{
 		Base:  NewBase(),
@@ -21,7 +22,7 @@ func Text(s StringEventPublisher) *TextWidget {
 			verticalAlign: Middle,
 		},
 	}
-	s.AddListener(l)
+	l.listen(s)
 	l.Base.SetWidget(l)
 	return l
 }
@@ -2,6 +2,9 @@ package widget
 
 import (
 	"fmt"
+	"html"
+
+	core "github.com/i2y/oden/core"
 )
 
 type TextAreaWidget struct {
@@ -13,24 +16,34 @@ func TextArea(placeholder string) *TextAreaWidget {
 	return TextAreaWithModel(NewTextAreaModel(placeholder))
 }
 
+// TextAreaWithState returns a text area bound to the given state.
+// Text entered in the browser is stored in the state through SetString,
+// and changes of the state are reflected in the text area.
+func TextAreaWithState(placeholder string, value StringEventPublisher) *TextAreaWidget {
+	return TextAreaWithModel(NewTextAreaModelWithState(placeholder, value))
+}
+
 func TextAreaWithModel(m *TextAreaModel) *TextAreaWidget {
 	t := &TextAreaWidget{
 		Base:  NewBase(),
 		model: m,
 	}
-	m.AddListener(t)
+	t.listen(m, m.value)
 	t.Base.SetWidget(t)
+	t.On("sl-input", t.receiveValue)
+	t.On("sl-change", t.receiveValue)
 	return t
 }
 
 func (t *TextAreaWidget) View() string {
 	return fmt.Sprintf(
-		`<sl-textarea id="%s" style="%s %s" placeholder="%s" size="medium" resize="none"></sl-textarea>
+		`<sl-textarea id="%s" style="%s %s" placeholder="%s" value="%s" size="medium" resize="none"></sl-textarea>
 		 <style>sl-textarea#%s::part(base) {%s; %s}</style>`,
 		t.ID(),
 		t.SizeStyle(),
 		t.OtherStyle(),
-		t.model.placeholder,
+		html.EscapeString(t.model.placeholder),
+		html.EscapeString(t.model.Value()),
 
 		t.ID(),
 		"--sl-textarea-height-medium: 100%",
@@ -38,15 +51,40 @@ func (t *TextAreaWidget) View() string {
 	)
 }
 
+func (t *TextAreaWidget) Value() string {
+	return t.model.Value()
+}
+
+func (t *TextAreaWidget) SetValue(value string) *TextAreaWidget {
+	t.model.SetValue(value)
+	return t
+}
+
+func (t *TextAreaWidget) receiveValue(ev core.Event) {
+	value, ok := ev.StringProp("value")
+	if !ok {
+		return
+	}
+	t.receive(func() {
+		t.model.value.SetString(value)
+	})
+}
+
 type TextAreaModel struct {
 	Model
 	placeholder string
+	value       StringEventPublisher
 }
 
 func NewTextAreaModel(placeholder string) *TextAreaModel {
+	return NewTextAreaModelWithState(placeholder, State(""))
+}
+
+func NewTextAreaModelWithState(placeholder string, value StringEventPublisher) *TextAreaModel {
 	return &TextAreaModel{
 		Model:       NewModel(),
 		placeholder: placeholder,
+		value:       value,
 	}
 }
 
@@ -57,3 +95,11 @@ func (sm *TextAreaModel) Placeholder() string {
 func (sm *TextAreaModel) SetPlaceholder(placeholder string) {
 	sm.placeholder = placeholder
 }
+
+func (sm *TextAreaModel) Value() string {
+	return sm.value.String()
+}
+
+func (sm *TextAreaModel) SetValue(value string) {
+	sm.value.SetString(value)
+}